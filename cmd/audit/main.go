@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+	"u2u-tps-benchmark/internal"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+	"github.com/unicornultrafoundation/go-u2u/crypto"
+	"github.com/unicornultrafoundation/go-u2u/ethclient"
+)
+
+func main() {
+	// Command-line flags
+	configFile := flag.String("config", "benchmark_config.json", "Path to config file")
+	rpcURL := flag.String("rpc", "", "RPC endpoint URL (overrides config)")
+	keysFile := flag.String("keys", "", "Path to private keys file (overrides config)")
+	numAccounts := flag.Int("accounts", 0, "Number of accounts to audit (0 = all, overrides config)")
+	output := flag.String("output", "nonce_audit.json", "Path to the JSON report (- = stdout)")
+	observe := flag.Int("observe", 15, "Seconds between the two snapshots used to detect stuck txs (0 = single snapshot)")
+
+	flag.Parse()
+
+	// With the report on stdout, progress goes to stderr so the JSON can be piped
+	reportOut := os.Stdout
+	if *output == "-" {
+		os.Stdout = os.Stderr
+	}
+
+	fmt.Println("╔════════════════════════════════════════╗")
+	fmt.Println("║        U2U Nonce & Balance Audit       ║")
+	fmt.Println("╚════════════════════════════════════════╝")
+
+	// Load or create config
+	var config *internal.Config
+	var err error
+
+	if *configFile != "" {
+		config, err = internal.LoadConfig(*configFile)
+		if os.IsNotExist(err) {
+			// If config file doesn't exist, use defaults
+			config = internal.DefaultConfig()
+		} else if err != nil {
+			log.Fatalf("\nFailed to load config: %v", err)
+		}
+	} else {
+		config = internal.DefaultConfig()
+	}
+
+	// Use config values, but allow flags to override
+	rpcEndpoint := config.RPCURL
+	if *rpcURL != "" {
+		rpcEndpoint = *rpcURL // Flag overrides config
+	}
+
+	keysFilePath := config.PrivateKeysFile
+	if *keysFile != "" {
+		keysFilePath = *keysFile // Flag overrides config
+	}
+
+	// Connect to RPC (the raw client is also used for txpool_content)
+	ctx := context.Background()
+	fmt.Printf("🔌 Connecting to RPC: %s\n", rpcEndpoint)
+	tlsConfig, err := config.TLSConfig()
+	if err != nil {
+		log.Fatalf("\nInvalid TLS settings: %v", err)
+	}
+	rpcClient, err := internal.DialRPC(ctx, rpcEndpoint, tlsConfig)
+	if err != nil {
+		log.Fatalf("\nFailed to connect to RPC: %v", err)
+	}
+	defer rpcClient.Close()
+	client := ethclient.NewClient(rpcClient)
+
+	chainID, err := internal.FetchChainID(ctx, client, config.GetStartupAttempts())
+	if err != nil {
+		log.Fatalf("\nFailed to get chain ID: %v", err)
+	}
+	fmt.Printf("✅ Connected to chain ID: %s\n\n", chainID.String())
+
+	// Load private keys (only the addresses are needed)
+	privateKeys, err := internal.LoadPrivateKeys(keysFilePath)
+	if err != nil {
+		log.Fatalf("\nFailed to load private keys: %v\n", err)
+	}
+
+	accountsToUse := *numAccounts
+	if accountsToUse == 0 && config.NumAccounts > 0 {
+		accountsToUse = config.NumAccounts
+	}
+	if accountsToUse > 0 && accountsToUse < len(privateKeys) {
+		privateKeys = privateKeys[:accountsToUse]
+	}
+	addresses := make([]common.Address, len(privateKeys))
+	for i, key := range privateKeys {
+		addresses[i] = crypto.PubkeyToAddress(key.PublicKey)
+	}
+	fmt.Printf("🔍 Auditing %d accounts\n", len(addresses))
+
+	audit, err := internal.AuditAccounts(ctx, client, rpcClient, addresses, time.Duration(*observe)*time.Second)
+	if err != nil {
+		log.Fatalf("\nFailed to audit accounts: %v", err)
+	}
+	audit.RPCURL = rpcEndpoint
+	audit.ChainID = chainID.String()
+
+	// Accounts that need attention
+	fmt.Println(strings.Repeat("=", 100))
+	fmt.Printf("%-8s | %-42s | %-9s | %-9s | %-8s | %s\n",
+		"Account", "Address", "Confirmed", "Pending", "Status", "Details")
+	fmt.Println(strings.Repeat("=", 100))
+	for _, a := range audit.Accounts {
+		if a.Status == internal.AuditOK {
+			continue
+		}
+		details := a.Error
+		switch {
+		case a.StuckTx != nil && a.StuckTx.Hash != "":
+			details = fmt.Sprintf("nonce %d stuck >%.0fs (%s)", a.StuckTx.Nonce, a.StuckTx.AgeSeconds, a.StuckTx.Hash)
+		case a.StuckTx != nil:
+			details = fmt.Sprintf("nonce %d stuck >%.0fs", a.StuckTx.Nonce, a.StuckTx.AgeSeconds)
+		case len(a.MissingNonces) > 0:
+			details = fmt.Sprintf("%d queued, missing nonces from %d", a.QueuedTxs, a.MissingNonces[0])
+		case a.PendingTxs > 0:
+			details = fmt.Sprintf("%d pending", a.PendingTxs)
+		}
+		fmt.Printf("%-8d | %-42s | %-9d | %-9d | %-8s | %s\n",
+			a.Account, a.Address, a.ConfirmedNonce, a.PendingNonce, a.Status, details)
+	}
+	fmt.Println(strings.Repeat("=", 100))
+
+	s := audit.Summary
+	fmt.Printf("📊 Summary: %d accounts — %d ok, %d pending, %d with gaps, %d stuck, %d unreadable\n",
+		s.Accounts, s.OK, s.Pending, s.Gap, s.Stuck, s.Errors)
+	if !audit.TxpoolAvailable {
+		fmt.Println("Note: txpool_content is not available on this node; gaps cannot be detected")
+	}
+
+	// Write the report
+	data, err := json.MarshalIndent(audit, "", "  ")
+	if err != nil {
+		log.Fatalf("\nFailed to encode report: %v", err)
+	}
+	if *output == "-" {
+		reportOut.Write(append(data, '\n'))
+		return
+	}
+	if err := os.WriteFile(*output, data, 0644); err != nil {
+		log.Fatalf("\nFailed to write report: %v", err)
+	}
+	fmt.Printf("📝 Audit report saved to %s\n", *output)
+}
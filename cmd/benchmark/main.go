@@ -6,7 +6,9 @@ import (
 	"flag"
 	"fmt"
 	"log"
-	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"u2u-tps-benchmark/internal"
@@ -20,9 +22,36 @@ func main() {
 	numAccounts := flag.Int("accounts", 10, "Number of accounts to use when no config file is supplied")
 	rpcURL := flag.String("rpc", "https://rpc-nebulas-testnet.uniultra.xyz", "RPC endpoint URL")
 	duration := flag.Int("duration", 60, "Benchmark duration in seconds")
+	soak := flag.Bool("soak", false, "Run as a soak test until stopped (ignores duration)")
+	untilInterrupt := flag.Bool("until-interrupt", false, "Ignore the duration and run until Ctrl+C, then print the final report")
+	gomaxprocs := flag.Int("gomaxprocs", 0, "Set GOMAXPROCS of the load generator (0 = Go default; overrides config)")
+	tracePhases := flag.Bool("trace-phases", false, "Report how long each phase of the run took, from connecting to the report (overrides config)")
+	debugRuntime := flag.Bool("debug-runtime", false, "Log goroutine count, heap and GC pauses of the load generator (overrides config)")
+	claimDir := flag.String("claim-dir", "", "Shared directory for account claims; refuses to start if another run uses the same accounts (overrides config)")
+	tpsHistogram := flag.Bool("tps-histogram", false, "Print an ASCII histogram of per-interval TPS in the final report (overrides config)")
+	warmCache := flag.Bool("warm-cache", false, "EXPERIMENTAL: reuse one pre-computed signature per worker to measure raw RPC submission rate")
+	printConfig := flag.Bool("print-config", false, "Print the effective config (after all overrides) as JSON and exit")
+	runLabel := flag.String("run-label", "", "Name for this run, added to the results and exported metrics (overrides config)")
+	pushgateway := flag.String("pushgateway", "", "Push the final results to this Prometheus pushgateway URL (overrides config)")
+	influxURL := flag.String("influx-url", "", "Write per-interval and final metrics to this InfluxDB write endpoint (overrides config)")
+	remoteSigner := flag.String("remote-signer", "", "Sign through this Clef-compatible signer endpoint instead of the keys file (overrides config)")
+	compareRPCs := flag.String("compare-rpcs", "", "Comma-separated RPC URLs to benchmark one after another with the same config, then compare")
+	compareConfigs := flag.String("compare-configs", "", "Comma-separated config files to benchmark one after another, then compare (paired with -compare-rpcs when both are set)")
+	compareResults := flag.String("compare-results", "", "Comma-separated saved results files (.json or .bin) to compare without running a benchmark")
+	minTPS := flag.Float64("min-tps", 0, "Exit with an error when the headline TPS (see headline_metric) is below this (overrides config)")
+	retryRun := flag.Int("retry-run", 0, "Retry the whole run up to N times (with backoff) when not a single transaction succeeds (overrides config)")
+	quiet := flag.Bool("quiet", false, "Send all output to stderr and print one key=value summary line to stdout")
+	noPreflight := flag.Bool("no-preflight", false, "Skip the nonce and balance reads and the balance check; accounts start at start_nonce (overrides config)")
+	calibrate := flag.Bool("calibrate", false, "Estimate the sustainable TPS with short runs at a rising target rate, then suggest a tps_schedule")
 
 	flag.Parse()
 
+	// In quiet mode everything except the final summary line goes to stderr
+	stdout := os.Stdout
+	if *quiet {
+		os.Stdout = os.Stderr
+	}
+
 	// Generate default config
 	if *generateConfig {
 		config := internal.DefaultConfig()
@@ -52,64 +81,433 @@ func main() {
 		config.PrivateKeysFile = *keysFile
 	}
 
+	// Flag overrides, also applied to every per-chain config with -compare-configs
+	applyFlags := func(c *internal.Config) {
+		if *soak {
+			c.SoakMode = true
+		}
+		if *untilInterrupt {
+			c.UntilInterrupt = true
+		}
+		if *claimDir != "" {
+			c.ClaimDir = *claimDir
+		}
+		if *tpsHistogram {
+			c.TPSHistogram = true
+		}
+		if *debugRuntime {
+			c.DebugRuntime = true
+		}
+		if *tracePhases {
+			c.TracePhases = true
+		}
+		if *gomaxprocs > 0 {
+			c.GoMaxProcs = *gomaxprocs
+		}
+		if *warmCache {
+			c.WarmCacheMode = true
+		}
+		if *remoteSigner != "" {
+			c.RemoteSignerURL = *remoteSigner
+		}
+		if *runLabel != "" {
+			c.RunLabel = *runLabel
+		}
+		if *pushgateway != "" {
+			c.PushgatewayURL = *pushgateway
+		}
+		if *influxURL != "" {
+			c.InfluxURL = *influxURL
+		}
+		if *minTPS > 0 {
+			c.MinTPS = *minTPS
+		}
+		if *retryRun > 0 {
+			c.RetryRuns = *retryRun
+		}
+		if *noPreflight {
+			c.NoPreflight = true
+		}
+	}
+	applyFlags(config)
+
+	// Fail fast on a malformed transfer amount
+	if _, err := config.TransferValue(); err != nil {
+		log.Fatalf("\nInvalid config: %v", err)
+	}
+
+	if *printConfig {
+		if err := config.WriteJSON(stdout); err != nil {
+			log.Fatalf("\nFailed to print config: %v", err)
+		}
+		return
+	}
+
+	// Compare saved results files, no benchmark run
+	if *compareResults != "" {
+		comparison, err := loadComparison(splitList(*compareResults))
+		if err != nil {
+			log.Fatalf("\nFailed to load results: %v", err)
+		}
+		comparison.Print()
+		return
+	}
+
 	fmt.Println("╔════════════════════════════════════════════╗")
 	fmt.Println("║        U2U Blockchain TPS Benchmark        ║")
 	fmt.Println("╚════════════════════════════════════════════╝")
 
+	// Several chains in sequence with a combined report
+	if *compareRPCs != "" || *compareConfigs != "" {
+		configs, err := comparisonConfigs(config, splitList(*compareRPCs), splitList(*compareConfigs), applyFlags)
+		if err != nil {
+			log.Fatalf("\nInvalid comparison: %v", err)
+		}
+		comparison := runComparison(configs, *configFile != "" || *compareConfigs != "")
+		comparison.Print()
+		if err := comparison.Save(config.OutputFile); err != nil {
+			log.Fatalf("\nFailed to save combined results: %v", err)
+		}
+		fmt.Printf("📝 Combined results saved to %s\n", config.OutputFile)
+
+		if *quiet {
+			for _, run := range comparison.Runs {
+				if run.Results != nil {
+					fmt.Fprintf(stdout, "chain=%s %s\n", run.Label, run.Results.SummaryLine())
+				}
+			}
+		}
+		for _, run := range comparison.Runs {
+			if run.Results != nil && run.Results.MinTPSFailed() {
+				log.Fatalf("\n%s: headline TPS %.2f is below min_tps %g", run.Label, run.Results.HeadlineTPS, run.Results.MinTPS)
+			}
+		}
+		return
+	}
+
+	// Short runs at a rising target rate instead of one benchmark
+	if *calibrate {
+		calibration := runCalibration(config, *configFile != "")
+		calibration.Print()
+		if err := calibration.Save(config.OutputFile); err != nil {
+			log.Fatalf("\nFailed to save calibration results: %v", err)
+		}
+		fmt.Printf("📝 Calibration results saved to %s\n", config.OutputFile)
+		if *quiet {
+			fmt.Fprintf(stdout, "sustainable_tps=%.2f suggested_tps=%.0f steps=%d\n",
+				calibration.EstimatedTPS, calibration.SuggestedTPS, len(calibration.Steps))
+		}
+		return
+	}
+
+	results, err := runWithRetries(config, *configFile != "")
+	if err != nil {
+		log.Fatalf("\nBenchmark failed: %v", err)
+	}
+
+	if *quiet && results != nil {
+		fmt.Fprintln(stdout, results.SummaryLine())
+	}
+	if results != nil && results.MinTPSFailed() {
+		log.Fatalf("\nHeadline TPS %.2f (%s) is below min_tps %g", results.HeadlineTPS, results.HeadlineMetric, results.MinTPS)
+	}
+}
+
+// runBenchmark connects to config.RPCURL, prepares the accounts and runs one benchmark.
+// With limitAccounts, only the first num_accounts keys of the keys file are used.
+func runBenchmark(config *internal.Config, limitAccounts bool) (*internal.Results, error) {
+	internal.ApplyGoMaxProcs(config)
+	phases := internal.NewPhaseTimer()
+	phases.Begin("connect")
+
 	// Connect to RPC with optimized connection pool
 	fmt.Printf("🔌 Connecting to RPC: %s\n", config.RPCURL)
-	// Use connection pool that supports 2000+ concurrent connections
-	client, err := internal.CreateOptimizedClient(config.RPCURL, 2000)
+	tlsConfig, err := config.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := internal.CreateOptimizedClient(config.RPCURL, config.GetMaxConnections(), tlsConfig, config.RetryPolicy())
 	if err != nil {
-		log.Fatalf("\nFailed to connect to RPC: %v", err)
+		return nil, fmt.Errorf("failed to connect to RPC: %v", err)
 	}
 	defer client.Close()
 
 	// Verify connection
-	chainID, err := client.ChainID(context.Background())
-	if err != nil {
-		log.Fatalf("\nFailed to get chain ID: %v", err)
+	// (a configured chain_id is resolved and checked in InitializeAccounts)
+	// (without one, transient failures are retried startup_attempts times)
+	attempts := config.GetStartupAttempts()
+	if config.ChainID > 0 {
+		attempts = 1
+	}
+	chainID, err := internal.FetchChainID(context.Background(), client, attempts)
+	switch {
+	case err == nil:
+		fmt.Printf("✅ Connected to chain ID: %s\n", chainID.String())
+	case config.ChainID > 0:
+		fmt.Printf("⚠️  Node did not report a chain ID (%v), continuing with configured chain_id %d\n", err, config.ChainID)
+	default:
+		return nil, fmt.Errorf("failed to get chain ID: %v", err)
 	}
-	fmt.Printf("✅ Connected to chain ID: %s\n", chainID.String())
 
-	// Load private keys
-	var privateKeys []*ecdsa.PrivateKey
+	phases.Begin("accounts")
+	var accounts []*internal.AccountSender
+	if config.RemoteSignerURL != "" {
+		// Keys stay with the remote signer; only addresses are known here
+		accounts, err = internal.InitializeRemoteAccounts(client, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize remote signer accounts: %v", err)
+		}
+	} else {
+		// Load private keys
+		var privateKeys []*ecdsa.PrivateKey
 
-	// Load existing keys
-	privateKeys, err = internal.LoadPrivateKeys(config.PrivateKeysFile)
-	if err != nil {
-		log.Fatalf("\nFailed to load private keys: %v\n", err)
-		log.Fatalf("\nHint: Use `go run cmd/generate-keys/main.go -accounts %d -output %s` to create keys", config.NumAccounts, config.PrivateKeysFile)
-	}
+		// Load existing keys (only the prefunded ones with a genesis file)
+		if config.GenesisFile != "" {
+			privateKeys, err = internal.LoadGenesisKeys(config.GenesisFile, config.PrivateKeysFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load genesis accounts: %v", err)
+			}
+		} else if privateKeys, err = internal.LoadPrivateKeys(config.PrivateKeysFile); err != nil {
+			return nil, fmt.Errorf("failed to load private keys: %v (use `go run cmd/generate-keys/main.go -accounts %d -output %s` to create keys)",
+				err, config.NumAccounts, config.PrivateKeysFile)
+		}
 
-	// Limit to num_accounts if specified and config file is used
-	if *configFile != "" && config.NumAccounts > 0 && config.NumAccounts < len(privateKeys) {
-		fmt.Printf("Using %d out of %d available accounts (as per config)\n", config.NumAccounts, len(privateKeys))
-		privateKeys = privateKeys[:config.NumAccounts]
+		// Limit to num_accounts if specified and config file is used
+		if limitAccounts && config.NumAccounts > 0 && config.NumAccounts < len(privateKeys) {
+			fmt.Printf("Using %d out of %d available accounts (as per config)\n", config.NumAccounts, len(privateKeys))
+			privateKeys = privateKeys[:config.NumAccounts]
+		}
+
+		// Initialize accounts
+		accounts, err = internal.InitializeAccounts(client, privateKeys, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize accounts: %v", err)
+		}
 	}
 
-	// Initialize accounts
-	accounts, err := internal.InitializeAccounts(client, privateKeys)
+	// Pin every account to one endpoint for the whole run (only with rpc_urls)
+	closeEndpoints, err := internal.PinEndpoints(context.Background(), config, accounts, tlsConfig)
 	if err != nil {
-		log.Fatalf("\nFailed to initialize accounts: %v", err)
+		return nil, fmt.Errorf("failed to pin accounts to endpoints: %v", err)
 	}
+	defer closeEndpoints()
 
-	// Check balances
-	minBalance := big.NewInt(1e17) // 0.1 U2U minimum (sufficient for ~50 transactions)
-	err = internal.CheckBalances(client, accounts, minBalance)
-	if err != nil {
-		log.Fatalf("\nFailed to check balances: %v", err)
+	// Deal with transactions left in the txpool by earlier runs
+	phases.Begin("reconcile")
+	if !config.IsReadWorkload() {
+		if err := internal.ReconcileNonces(context.Background(), config, accounts); err != nil {
+			return nil, fmt.Errorf("failed to reconcile nonces: %v", err)
+		}
+	}
+
+	skippedAccounts := 0
+
+	// Check balances against the configured (or estimated) minimum
+	// (read workloads send no transactions, so any balance will do; no_preflight trusts the accounts)
+	if !config.IsReadWorkload() && !config.NoPreflight {
+		phases.Begin("balance_check")
+		gas, err := internal.ResolveGasSettings(context.Background(), client, config.FixedGasPriceWei, config.EIP1559, config.Tip())
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve gas price: %v", err)
+		}
+		minBalance, err := internal.MinimumBalance(config, gas.GasPrice)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine minimum balance: %v", err)
+		}
+		fmt.Printf("💰 Minimum balance per account: %s U2U (%s)\n",
+			internal.FormatU2U(minBalance), internal.DescribeMinimumBalance(config))
+		if config.FaucetURL != "" {
+			phases.Begin("funding")
+			if err := internal.FaucetTopUp(context.Background(), config, client, accounts, minBalance); err != nil {
+				return nil, fmt.Errorf("failed to top up from faucet: %v", err)
+			}
+			phases.Begin("balance_check")
+		}
+		funded, err := internal.CheckBalances(client, accounts, minBalance, config.SkipUnderfundedAccounts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check balances: %v", err)
+		}
+		skippedAccounts = len(accounts) - len(funded)
+		accounts = funded
 	}
 
 	// Create and start benchmark
+	phases.Begin("setup")
 	benchmark, err := internal.NewBenchmark(config, client, accounts)
 	if err != nil {
-		log.Fatalf("\nFailed to create benchmark: %v", err)
+		return nil, fmt.Errorf("failed to create benchmark: %v", err)
+	}
+	benchmark.SetSkippedAccounts(skippedAccounts)
+	benchmark.SetPhaseTimer(phases)
+	if config.TrackPropagation {
+		if err := benchmark.EnablePropagation(tlsConfig); err != nil {
+			fmt.Printf("⚠️  Propagation tracking skipped: %v\n", err)
+		}
+	}
+
+	// Refuse to start if another load generator is using the same accounts
+	if config.ClaimDir != "" {
+		release, err := internal.ClaimAccounts(config.ClaimDir, accounts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim accounts: %v", err)
+		}
+		defer release()
+		fmt.Printf("🔒 Claimed %d accounts in %s\n", len(accounts), config.ClaimDir)
 	}
 
 	// Confirmation prompt
+	phases.Begin("countdown")
 	fmt.Println("⚡ Ready to start benchmark. Press Ctrl+C to abort, or wait 5 seconds...")
 	time.Sleep(5 * time.Second)
 
 	benchmark.Start()
+	return benchmark.Results(), nil
+}
+
+// Backoff before a whole-run retry: doubles from runRetryBackoff up to runRetryBackoffMax
+const (
+	runRetryBackoff    = 10 * time.Second
+	runRetryBackoffMax = 2 * time.Minute
+)
+
+// runWithRetries runs the benchmark, and runs it again up to retry_runs times while a run
+// ends without a single successful transaction (a node hiccup at startup, not a slow chain).
+// Runs with any success are never retried, so real performance problems are not masked.
+func runWithRetries(config *internal.Config, limitAccounts bool) (*internal.Results, error) {
+	backoff := runRetryBackoff
+	for attempt := 1; ; attempt++ {
+		results, err := runBenchmark(config, limitAccounts)
+		if err != nil || results == nil || results.TotalSubmitted > 0 || results.StopReason == "interrupted" {
+			return results, err
+		}
+		if attempt > config.RetryRuns {
+			if config.RetryRuns > 0 {
+				fmt.Printf("❌ No transaction succeeded in %d attempts, giving up\n", attempt)
+			}
+			return results, nil
+		}
+
+		fmt.Printf("\n🔁 No transaction succeeded; retrying the whole run in %v (retry %d/%d)\n", backoff, attempt, config.RetryRuns)
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > runRetryBackoffMax {
+			backoff = runRetryBackoffMax
+		}
+	}
+}
+
+// comparisonConfigs returns one config per chain. Per-chain config files replace the base
+// config (flags still apply); RPC URLs override rpc_url, pairing up with the files by position.
+func comparisonConfigs(base *internal.Config, rpcs, files []string, applyFlags func(*internal.Config)) ([]*internal.Config, error) {
+	if len(rpcs) > 0 && len(files) > 0 && len(rpcs) != len(files) {
+		return nil, fmt.Errorf("%d RPC URLs but %d config files", len(rpcs), len(files))
+	}
+
+	count := max(len(rpcs), len(files))
+	configs := make([]*internal.Config, count)
+	for i := range configs {
+		config := new(internal.Config)
+		*config = *base
+		if len(files) > 0 {
+			loaded, err := internal.LoadConfig(files[i])
+			if err != nil {
+				return nil, err
+			}
+			applyFlags(loaded)
+			config = loaded
+		}
+		if len(rpcs) > 0 {
+			config.RPCURL = rpcs[i]
+		}
+		if _, err := config.TransferValue(); err != nil {
+			return nil, fmt.Errorf("%s: %v", internal.ChainLabel(config), err)
+		}
+		configs[i] = config
+	}
+	return configs, nil
+}
+
+// runComparison benchmarks every config in turn. Each run writes its own numbered
+// output files; a failed run is recorded and the next chain still runs.
+func runComparison(configs []*internal.Config, limitAccounts bool) *internal.ComparisonResults {
+	runs := make([]internal.ChainRun, len(configs))
+	for i, config := range configs {
+		config.OutputFile = internal.IndexedFilename(config.OutputFile, i+1)
+		config.TxHashLogFile = internal.IndexedFilename(config.TxHashLogFile, i+1)
+		config.StreamFile = internal.IndexedFilename(config.StreamFile, i+1)
+		config.PrometheusFile = internal.IndexedFilename(config.PrometheusFile, i+1)
+		config.InfluxFile = internal.IndexedFilename(config.InfluxFile, i+1)
+		config.PhaseTraceFile = internal.IndexedFilename(config.PhaseTraceFile, i+1)
+
+		runs[i] = internal.ChainRun{Label: internal.ChainLabel(config), RPCURL: config.RPCURL}
+		for j := 0; j < i; j++ {
+			if runs[j].Label == runs[i].Label {
+				runs[i].Label = fmt.Sprintf("%s #%d", runs[i].Label, i+1)
+				break
+			}
+		}
+
+		fmt.Printf("\n🔗 Chain %d/%d: %s\n", i+1, len(configs), runs[i].Label)
+		results, err := runWithRetries(config, limitAccounts)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", runs[i].Label, err)
+			runs[i].Error = err.Error()
+			continue
+		}
+		runs[i].Results = results
+	}
+	return internal.NewComparison(runs)
+}
+
+// runCalibration runs one short step per target rate until a step fails its thresholds.
+// Each step writes its own numbered output files; an interrupted step ends the calibration.
+func runCalibration(config *internal.Config, limitAccounts bool) *internal.CalibrationResults {
+	targets := config.CalibrationTargets()
+	var steps []internal.CalibrationStep
+	for i, target := range targets {
+		fmt.Printf("\n🎯 Calibration step %d/%d: %.0f TPS for %ds\n", i+1, len(targets), target, config.GetCalibrateStepSeconds())
+		results, err := runBenchmark(config.CalibrationStepConfig(target, i+1), limitAccounts)
+		if err != nil || results == nil {
+			step := internal.CalibrationStep{TargetTPS: target, Error: "run did not complete"}
+			if err != nil {
+				step.Error = err.Error()
+			}
+			steps = append(steps, step)
+			break
+		}
+		step := config.EvaluateCalibrationStep(target, results)
+		steps = append(steps, step)
+		if !step.Passed || results.StopReason == "interrupted" {
+			break
+		}
+	}
+	return internal.NewCalibrationResults(steps)
+}
+
+// loadComparison builds a comparison from saved results files, labeled by run_label or file name
+func loadComparison(files []string) (*internal.ComparisonResults, error) {
+	runs := make([]internal.ChainRun, 0, len(files))
+	for _, file := range files {
+		results, err := internal.LoadResults(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+		label := results.RunLabel
+		if label == "" {
+			label = filepath.Base(file)
+		}
+		rpcURL, _ := results.Config["rpc_url"].(string)
+		runs = append(runs, internal.ChainRun{Label: label, RPCURL: rpcURL, Results: results})
+	}
+	return internal.NewComparison(runs), nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
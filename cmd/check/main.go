@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
 	"strings"
 	"u2u-tps-benchmark/internal"
 
@@ -30,9 +31,11 @@ func main() {
 
 	if *configFile != "" {
 		config, err = internal.LoadConfig(*configFile)
-		if err != nil {
+		if os.IsNotExist(err) {
 			// If config file doesn't exist, use defaults
 			config = internal.DefaultConfig()
+		} else if err != nil {
+			log.Fatalf("\nFailed to load config: %v", err)
 		}
 	} else {
 		config = internal.DefaultConfig()
@@ -51,14 +54,19 @@ func main() {
 
 	// Connect to RPC
 	fmt.Printf("🔌 Connecting to RPC: %s\n", rpcEndpoint)
-	client, err := ethclient.Dial(rpcEndpoint)
+	tlsConfig, err := config.TLSConfig()
+	if err != nil {
+		log.Fatalf("\nInvalid TLS settings: %v", err)
+	}
+	rpcClient, err := internal.DialRPC(context.Background(), rpcEndpoint, tlsConfig)
 	if err != nil {
 		log.Fatalf("\nFailed to connect to RPC: %v", err)
 	}
+	client := ethclient.NewClient(rpcClient)
 	defer client.Close()
 
 	// Verify connection
-	chainID, err := client.ChainID(context.Background())
+	chainID, err := internal.FetchChainID(context.Background(), client, config.GetStartupAttempts())
 	if err != nil {
 		log.Fatalf("\nFailed to get chain ID: %v", err)
 	}
@@ -88,7 +96,7 @@ func main() {
 	}
 
 	// Initialize accounts
-	accounts, err := internal.InitializeAccounts(client, privateKeys)
+	accounts, err := internal.InitializeAccounts(client, privateKeys, config)
 	if err != nil {
 		log.Fatalf("\nFailed to initialize accounts: %v", err)
 	}
@@ -2,13 +2,17 @@ package main
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"flag"
 	"fmt"
 	"log"
 	"math/big"
 	"os"
+	"strings"
+	"time"
 	"u2u-tps-benchmark/internal"
 
+	"github.com/unicornultrafoundation/go-u2u/common"
 	"github.com/unicornultrafoundation/go-u2u/core/types"
 	"github.com/unicornultrafoundation/go-u2u/crypto"
 	"github.com/unicornultrafoundation/go-u2u/ethclient"
@@ -19,8 +23,20 @@ func main() {
 	configFile := flag.String("config", "benchmark_config.json", "Path to config file")
 	rpcURL := flag.String("rpc", "", "RPC endpoint URL (overrides config)")
 	keysFile := flag.String("keys", "", "Path to private keys file (overrides config)")
-	amount := flag.String("amount", "1", "Amount to fund per account in U2U")
+	amount := flag.String("amount", "1", "Amount to fund per account (U2U by default, or with a unit: \"500 gwei\")")
+	weights := flag.String("weights", "", "Per-account multiples of -amount, e.g. \"0-1:50,2:10\" (unlisted accounts: 1, 0 = skip)")
+	amountFile := flag.String("amount-file", "", "CSV of accountIndex,amount rows with exact amounts (bare numbers in U2U)")
 	numAccounts := flag.Int("accounts", 0, "Number of accounts to fund (0 = all, overrides config)")
+	gasPriceFlag := flag.String("gas-price", "", "Gas price in wei or with a unit (e.g. \"5 gwei\"), fee cap with -eip1559 (overrides config, default: node suggestion)")
+	eip1559 := flag.Bool("eip1559", false, "Send dynamic-fee (EIP-1559) funding transactions")
+	disperse := flag.String("disperse", "", "Disperse contract address: fund accounts in batched disperseEther calls (overrides config)")
+	batchSize := flag.Int("batch-size", internal.DefaultDisperseBatchSize, "Recipients per disperse call")
+	replaceTimeout := flag.Int("replace-timeout", 60, "Seconds to wait for funding txs to be mined before rebroadcasting them with a higher gas price (0 = don't wait)")
+	replaceBump := flag.Float64("replace-bump", 20, "Gas price increase per rebroadcast, in percent (nodes require at least 10)")
+	maxReplacements := flag.Int("max-replacements", 3, "Rebroadcasts per stuck funding tx before giving up")
+	confirmations := flag.Int("confirmations", 0, "Blocks that must be built on top of the funding txs before they count as confirmed (0 = mined is enough)")
+	funderKeyFile := flag.String("funder-key-file", "", "File with the funder's hex private key or keystore entry (default: FUNDER_PRIVATE_KEY)")
+	funderKeyPasswordFile := flag.String("funder-key-password-file", "", "File with the keystore passphrase (default: FUNDER_KEY_PASSWORD)")
 
 	flag.Parse()
 
@@ -28,21 +44,22 @@ func main() {
 	fmt.Println("║          U2U Account Funding         ║")
 	fmt.Println("╚══════════════════════════════════════╝")
 
-	// Get funder private key from environment
-	funderPrivateKeyHex := os.Getenv("FUNDER_PRIVATE_KEY")
-	if funderPrivateKeyHex == "" {
-		log.Fatal("\nFUNDER_PRIVATE_KEY environment variable is not set")
+	// Get funder private key from a file or the environment
+	funderKey, err := internal.LoadFunderKey(*funderKeyFile, *funderKeyPasswordFile)
+	if err != nil {
+		log.Fatalf("\n%v", err)
 	}
 
 	// Load or create config
 	var config *internal.Config
-	var err error
 
 	if *configFile != "" {
 		config, err = internal.LoadConfig(*configFile)
-		if err != nil {
+		if os.IsNotExist(err) {
 			// If config file doesn't exist, use defaults
 			config = internal.DefaultConfig()
+		} else if err != nil {
+			log.Fatalf("\nFailed to load config: %v", err)
 		}
 	} else {
 		config = internal.DefaultConfig()
@@ -59,27 +76,46 @@ func main() {
 		keysFilePath = *keysFile // Flag overrides config
 	}
 
+	disperseAddress := config.DisperseContractAddress
+	if *disperse != "" {
+		disperseAddress = *disperse // Flag overrides config
+	}
+	if disperseAddress != "" && !common.IsHexAddress(disperseAddress) {
+		log.Fatalf("\nInvalid disperse contract address: %q", disperseAddress)
+	}
+	if *batchSize <= 0 {
+		log.Fatalf("\n-batch-size must be positive")
+	}
+	if *replaceBump < 10 {
+		log.Fatalf("\n-replace-bump must be at least 10 (percent); nodes reject smaller replacements")
+	}
+	if *confirmations < 0 {
+		log.Fatalf("\n-confirmations must not be negative")
+	}
+	if *confirmations > 0 && *replaceTimeout <= 0 {
+		log.Fatalf("\n-confirmations needs -replace-timeout > 0 (the command must wait for the funding txs)")
+	}
+
 	// Connect to RPC
 	fmt.Printf("🔌 Connecting to RPC: %s\n", rpcEndpoint)
-	client, err := ethclient.Dial(rpcEndpoint)
+	tlsConfig, err := config.TLSConfig()
+	if err != nil {
+		log.Fatalf("\nInvalid TLS settings: %v", err)
+	}
+	rpcClient, err := internal.DialRPC(context.Background(), rpcEndpoint, tlsConfig)
 	if err != nil {
 		log.Fatalf("\nFailed to connect to RPC: %v", err)
 	}
+	client := ethclient.NewClient(rpcClient)
 	defer client.Close()
 
 	// Verify connection
-	chainID, err := client.ChainID(context.Background())
+	chainID, err := internal.ResolveChainID(context.Background(), client, config)
 	if err != nil {
-		log.Fatalf("\nFailed to get chain ID: %v", err)
+		log.Fatalf("\nFailed to resolve chain ID: %v", err)
 	}
 	fmt.Printf("✅ Connected to chain ID: %s\n\n", chainID.String())
 
-	// Parse funder private key
-	funderKey, err := crypto.HexToECDSA(funderPrivateKeyHex)
-	if err != nil {
-		log.Fatalf("\nInvalid private key: %v", err)
-	}
-
 	funderAddr := crypto.PubkeyToAddress(funderKey.PublicKey)
 	fmt.Printf("👤 Funder Address: %s\n", funderAddr.Hex())
 
@@ -117,24 +153,47 @@ func main() {
 		fmt.Printf("💸 Funding %d accounts\n", len(testKeys))
 	}
 
-	// Parse funding amount
-	amountFloat, _ := new(big.Float).SetString(*amount)
-	totalNeeded := new(big.Float).Mul(
-		big.NewFloat(float64(len(testKeys))),
-		amountFloat,
-	)
-	fmt.Printf("💵 Amount per account: %s U2U\n", *amount)
-	fmt.Printf("💰 Total needed: %.2f U2U\n\n", totalNeeded)
-
-	// Check if funder has sufficient balance
-	if balanceU2U.Cmp(totalNeeded) < 0 {
-		log.Fatalf("\n❌ Funder has insufficient balance! Need %.2f U2U, have %.6f U2U", totalNeeded, balanceU2U)
+	// Parse funding amounts (bare numbers are U2U), weighted per account
+	amountWei, err := internal.ParseAmount(*amount, "u2u")
+	if err != nil {
+		log.Fatalf("\nInvalid -amount: %v", err)
+	}
+	amounts, err := internal.FundingAmounts(len(testKeys), amountWei, *weights, *amountFile)
+	if err != nil {
+		log.Fatalf("\nInvalid funding amounts: %v", err)
 	}
+	var fundable []int // Accounts with a non-zero amount
+	totalValue := new(big.Int)
+	for i, a := range amounts {
+		if a.Sign() > 0 {
+			fundable = append(fundable, i)
+			totalValue.Add(totalValue, a)
+		}
+	}
+	if len(fundable) == 0 {
+		log.Fatalf("\nEvery account has a zero amount; nothing to fund")
+	}
+	printAmounts(amounts, amountWei)
 
-	// Get gas price
-	gasPrice, err := client.SuggestGasPrice(context.Background())
+	// Resolve gas pricing (flags override config)
+	fixedGasPrice := config.FixedGasPriceWei
+	if *gasPriceFlag != "" {
+		fixedGasPrice = *gasPriceFlag
+	}
+	gas, err := internal.ResolveGasSettings(context.Background(), client, fixedGasPrice, *eip1559 || config.EIP1559, config.Tip())
 	if err != nil {
-		log.Fatalf("\nFailed to get gas price: %v", err)
+		log.Fatalf("\nFailed to resolve gas price: %v", err)
+	}
+	fmt.Printf("⛽ Gas Price: %s\n", gas.String())
+
+	// Check if funder has sufficient balance for the value plus the gas of every funding tx
+	gasCost := fundingGasCost(worstCaseGas(gas, *replaceTimeout, *replaceBump, *maxReplacements),
+		len(fundable), disperseAddress != "", *batchSize)
+	totalNeeded := new(big.Int).Add(totalValue, gasCost)
+	fmt.Printf("💰 Total needed: %s U2U (%s U2U value + up to %s U2U gas)\n\n",
+		internal.FormatU2U(totalNeeded), internal.FormatU2U(totalValue), internal.FormatU2U(gasCost))
+	if balance.Cmp(totalNeeded) < 0 {
+		log.Fatalf("\n❌ Funder has insufficient balance! Need %s U2U, have %.6f U2U", internal.FormatU2U(totalNeeded), balanceU2U)
 	}
 
 	// Get starting nonce
@@ -143,43 +202,313 @@ func main() {
 		log.Fatalf("\nFailed to get nonce: %v", err)
 	}
 
-	// Convert amount to wei
-	amountWei := new(big.Int)
-	amountWei.SetString(*amount+"000000000000000000", 10)
+	ctx := context.Background()
+
+	// Batched funding through a Disperse contract
+	if disperseAddress != "" {
+		contract := common.HexToAddress(disperseAddress)
+		code, err := client.CodeAt(ctx, contract, nil)
+		if err != nil {
+			log.Fatalf("\nFailed to check disperse contract: %v", err)
+		}
+		if len(code) == 0 {
+			log.Fatalf("\nNo contract deployed at disperse address %s", contract.Hex())
+		}
+
+		funded, sent := fundWithDisperse(ctx, client, gas, chainID, funderKey, nonce, contract, testKeys, amounts, fundable, *batchSize)
+		awaitFunding(ctx, client, chainID, funderKey, sent, *replaceTimeout, *replaceBump, *maxReplacements)
+		awaitConfirmations(ctx, client, funderAddr, sent, *confirmations, *replaceTimeout)
+		fmt.Printf("\n✅ Successfully funded %d/%d accounts\n", funded, len(fundable))
+		return
+	}
 
 	// Start funding
 	fmt.Println("💸 Starting to fund accounts...")
 
-	ctx := context.Background()
 	successCount := 0
 	errorCount := 0
+	var sent []*fundingTx
 
-	for i, key := range testKeys {
-		to := crypto.PubkeyToAddress(key.PublicKey)
+	for _, i := range fundable {
+		to := crypto.PubkeyToAddress(testKeys[i].PublicKey)
 
 		// Create transaction
-		tx := types.NewTransaction(nonce, to, amountWei, 21000, gasPrice, nil)
-		signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), funderKey)
+		ftx := &fundingTx{
+			label: fmt.Sprintf("Account %2d", i),
+			nonce: nonce, to: to, value: amounts[i], gasLimit: 21000, gas: gas,
+		}
+		signedTx, err := ftx.send(ctx, client, chainID, funderKey)
 		if err != nil {
-			fmt.Printf("❌ Account %2d: %s - Failed to sign: %v\n", i, to.Hex(), err)
+			fmt.Printf("❌ Account %2d: %s - %v\n", i, to.Hex(), err)
 			errorCount++
+			continue // The nonce stays free for the next account, so no gap blocks later txs
+		}
+
+		fmt.Printf("✅ Account %2d: %s (tx: %s)\n", i, to.Hex(), shortHash(signedTx.Hash()))
+		successCount++
+		sent = append(sent, ftx)
+		nonce++
+	}
+	awaitFunding(ctx, client, chainID, funderKey, sent, *replaceTimeout, *replaceBump, *maxReplacements)
+	awaitConfirmations(ctx, client, funderAddr, sent, *confirmations, *replaceTimeout)
+	fmt.Printf("\n✅ Successfully funded %d/%d accounts\n", successCount, len(fundable))
+}
+
+// fundingTx is a sent funding transaction, kept so it can be rebroadcast at the same nonce
+type fundingTx struct {
+	label    string
+	nonce    uint64
+	to       common.Address
+	value    *big.Int
+	gasLimit uint64
+	data     []byte
+	gas      *internal.GasSettings
+}
+
+// send signs and submits the transaction with its current gas settings
+func (f *fundingTx) send(ctx context.Context, client *ethclient.Client, chainID *big.Int, funderKey *ecdsa.PrivateKey) (*types.Transaction, error) {
+	tx := f.gas.NewTx(chainID, f.nonce, f.to, f.value, f.gasLimit, f.data)
+	signedTx, err := internal.SignTransaction(tx, chainID, funderKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: %v", err)
+	}
+	if err := client.SendTransaction(ctx, signedTx); err != nil {
+		return nil, fmt.Errorf("failed to send: %v", err)
+	}
+	return signedTx, nil
+}
+
+// shortHash truncates a transaction hash for display (first 10 + last 8 chars)
+func shortHash(hash common.Hash) string {
+	h := hash.Hex()
+	return h[:10] + "..." + h[len(h)-8:]
+}
+
+// awaitFunding waits until the funder's mined nonce passes every sent transaction. Transactions
+// still pending after timeoutSeconds are rebroadcast at the same nonce with the gas price raised
+// by bumpPercent, up to maxReplacements times; if some are still stuck after that the command
+// fails, since a stuck nonce also blocks every later funding transaction.
+func awaitFunding(ctx context.Context, client *ethclient.Client, chainID *big.Int, funderKey *ecdsa.PrivateKey,
+	pending []*fundingTx, timeoutSeconds int, bumpPercent float64, maxReplacements int) {
+	if timeoutSeconds <= 0 || len(pending) == 0 {
+		return
+	}
+	funder := crypto.PubkeyToAddress(funderKey.PublicKey)
+	timeout := time.Duration(timeoutSeconds) * time.Second
+
+	fmt.Printf("\n⏳ Waiting up to %v for %d funding transactions to be mined...\n", timeout, len(pending))
+	for round := 0; ; round++ {
+		deadline := time.Now().Add(timeout)
+		for len(pending) > 0 && time.Now().Before(deadline) {
+			time.Sleep(2 * time.Second)
+			mined, err := client.NonceAt(ctx, funder, nil)
+			if err != nil {
+				continue // Transient; try again on the next poll
+			}
+			pending = stillPending(pending, mined)
+		}
+		if len(pending) == 0 {
+			fmt.Printf("✅ All funding transactions mined\n")
+			return
+		}
+		if round == maxReplacements {
+			for _, f := range pending {
+				fmt.Printf("❌ %s: %s - still pending at nonce %d\n", f.label, f.to.Hex(), f.nonce)
+			}
+			log.Fatalf("\n❌ %d funding transactions not mined after %d rebroadcasts; raise -gas-price or -replace-bump and rerun",
+				len(pending), maxReplacements)
+		}
+
+		fmt.Printf("🔁 %d funding transactions not mined after %v, rebroadcasting with +%g%% gas price (%d/%d)\n",
+			len(pending), timeout, bumpPercent, round+1, maxReplacements)
+		var resent []*fundingTx
+		for _, f := range pending {
+			f.gas = bumpGas(f.gas, bumpPercent)
+			signedTx, err := f.send(ctx, client, chainID, funderKey)
+			switch {
+			case err != nil && strings.Contains(strings.ToLower(err.Error()), "nonce too low"):
+				continue // Mined since the last poll
+			case err != nil:
+				fmt.Printf("⚠️  %s: %s - nonce %d: %v\n", f.label, f.to.Hex(), f.nonce, err)
+			default:
+				fmt.Printf("🔁 %s: %s - nonce %d at %s (tx: %s)\n", f.label, f.to.Hex(), f.nonce, f.gas, shortHash(signedTx.Hash()))
+			}
+			resent = append(resent, f)
+		}
+		pending = resent
+	}
+}
+
+// awaitConfirmations waits until every sent transaction is buried under the given number of
+// blocks: the funder's nonce as of block head-confirmations must pass the last funding nonce.
+// Reading the nonce at that depth instead of following receipts also covers rebroadcasts (whose
+// hashes differ) and reorgs: a transaction reorged out lowers the nonce again until it is re-mined.
+// The command fails when the chain head does not advance for timeoutSeconds.
+func awaitConfirmations(ctx context.Context, client *ethclient.Client, funder common.Address,
+	sent []*fundingTx, confirmations int, timeoutSeconds int) {
+	if confirmations <= 0 || len(sent) == 0 {
+		return
+	}
+	target := uint64(0)
+	for _, f := range sent {
+		target = max(target, f.nonce+1)
+	}
+	stallTimeout := time.Duration(timeoutSeconds) * time.Second
+
+	fmt.Printf("⏳ Waiting for %d confirmations on top of the funding transactions...\n", confirmations)
+	var lastHead uint64
+	lastProgress := time.Now()
+	reorged := false
+	for {
+		head, err := client.BlockNumber(ctx)
+		if err == nil {
+			if head != lastHead {
+				lastHead = head
+				lastProgress = time.Now()
+			}
+			if head >= uint64(confirmations) {
+				buried, err := client.NonceAt(ctx, funder, new(big.Int).SetUint64(head-uint64(confirmations)))
+				if err == nil && buried >= target {
+					fmt.Printf("✅ All funding transactions have %d confirmations (head block %d)\n", confirmations, head)
+					return
+				}
+			}
+			// A mined nonce below the target means funding txs were reorged out
+			if mined, err := client.NonceAt(ctx, funder, nil); err == nil && mined < target && !reorged {
+				reorged = true
+				fmt.Printf("⚠️  Funder nonce at the head dropped to %d (reorg); waiting for the funding txs to be mined again\n", mined)
+			}
+		}
+		if time.Since(lastProgress) > stallTimeout {
+			log.Fatalf("\n❌ Chain head stuck at block %d for %v while waiting for %d confirmations", lastHead, stallTimeout, confirmations)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// stillPending drops the transactions below the funder's mined nonce
+func stillPending(pending []*fundingTx, minedNonce uint64) []*fundingTx {
+	var rest []*fundingTx
+	for _, f := range pending {
+		if f.nonce >= minedNonce {
+			rest = append(rest, f)
+		}
+	}
+	return rest
+}
+
+// bumpGas raises the gas price (and tip) by percent, by at least 1 wei each
+func bumpGas(gas *internal.GasSettings, percent float64) *internal.GasSettings {
+	bumped := gas.Scaled(1 + percent/100)
+	if bumped.GasPrice.Cmp(gas.GasPrice) <= 0 {
+		bumped.GasPrice = new(big.Int).Add(gas.GasPrice, big.NewInt(1))
+	}
+	if gas.GasTipCap != nil && bumped.GasTipCap.Cmp(gas.GasTipCap) <= 0 {
+		bumped.GasTipCap = new(big.Int).Add(gas.GasTipCap, big.NewInt(1))
+	}
+	return bumped
+}
+
+// printAmounts shows the amount per account, or its range and the skipped accounts when
+// -weights or -amount-file made them differ
+func printAmounts(amounts []*big.Int, base *big.Int) {
+	var lowest, highest *big.Int
+	skipped, custom := 0, 0
+	for _, a := range amounts {
+		if a.Cmp(base) != 0 {
+			custom++
+		}
+		if a.Sign() == 0 {
+			skipped++
 			continue
 		}
+		if lowest == nil || a.Cmp(lowest) < 0 {
+			lowest = a
+		}
+		if highest == nil || a.Cmp(highest) > 0 {
+			highest = a
+		}
+	}
+	if custom == 0 {
+		fmt.Printf("💵 Amount per account: %s U2U\n", internal.FormatU2U(base))
+		return
+	}
+	if lowest != nil {
+		fmt.Printf("💵 Amount per account: %s-%s U2U (%d of %d accounts not at -amount)\n",
+			internal.FormatU2U(lowest), internal.FormatU2U(highest), custom, len(amounts))
+	}
+	if skipped > 0 {
+		fmt.Printf("⏭️  Skipping %d accounts with a zero amount\n", skipped)
+	}
+}
+
+// worstCaseGas is the gas price of the last rebroadcast awaitFunding can make (gas itself
+// when the command doesn't wait for the funding transactions)
+func worstCaseGas(gas *internal.GasSettings, timeoutSeconds int, bumpPercent float64, maxReplacements int) *internal.GasSettings {
+	if timeoutSeconds <= 0 {
+		return gas
+	}
+	for i := 0; i < maxReplacements; i++ {
+		gas = bumpGas(gas, bumpPercent)
+	}
+	return gas
+}
+
+// fundingGasCost is the most the funding transactions can spend on gas: one 21000-gas
+// transfer per account, or one disperseEther call per batch of up to batchSize accounts
+func fundingGasCost(gas *internal.GasSettings, accounts int, disperse bool, batchSize int) *big.Int {
+	var gasUnits uint64
+	if disperse {
+		for start := 0; start < accounts; start += batchSize {
+			gasUnits += internal.DisperseGasLimit(min(batchSize, accounts-start))
+		}
+	} else {
+		gasUnits = uint64(accounts) * 21000
+	}
+	return new(big.Int).Mul(gas.GasPrice, new(big.Int).SetUint64(gasUnits))
+}
+
+// fundWithDisperse pays every account through disperseEther calls of up to batchSize
+// recipients each and returns how many accounts were covered by accepted calls, and the calls
+func fundWithDisperse(ctx context.Context, client *ethclient.Client, gas *internal.GasSettings, chainID *big.Int,
+	funderKey *ecdsa.PrivateKey, nonce uint64, contract common.Address, keys []*ecdsa.PrivateKey, amounts []*big.Int,
+	fundable []int, batchSize int) (int, []*fundingTx) {
+	batches := (len(fundable) + batchSize - 1) / batchSize
+	fmt.Printf("💸 Funding through Disperse contract %s (%d calls of up to %d accounts)...\n",
+		contract.Hex(), batches, batchSize)
+
+	funded := 0
+	var sent []*fundingTx
+	for start := 0; start < len(fundable); start += batchSize {
+		batch := fundable[start:min(start+batchSize, len(fundable))]
+		first, last := batch[0], batch[len(batch)-1]
+
+		recipients := make([]common.Address, 0, len(batch))
+		values := make([]*big.Int, 0, len(batch))
+		total := new(big.Int)
+		for _, i := range batch {
+			recipients = append(recipients, crypto.PubkeyToAddress(keys[i].PublicKey))
+			values = append(values, amounts[i])
+			total.Add(total, amounts[i])
+		}
 
-		// Send transaction
-		err = client.SendTransaction(ctx, signedTx)
+		ftx := &fundingTx{
+			label: fmt.Sprintf("Accounts %d-%d", first, last),
+			nonce: nonce, to: contract, value: total, gas: gas,
+			gasLimit: internal.DisperseGasLimit(len(recipients)),
+			data:     internal.DisperseCalldata(recipients, values),
+		}
+		signedTx, err := ftx.send(ctx, client, chainID, funderKey)
 		if err != nil {
-			fmt.Printf("❌ Account %2d: %s - Failed to send: %v\n", i, to.Hex(), err)
-			errorCount++
-		} else {
-			// Truncate transaction hash for display (first 10 + last 8 chars)
-			txHash := signedTx.Hash().Hex()
-			txHashShort := txHash[:10] + "..." + txHash[len(txHash)-8:]
-			fmt.Printf("✅ Account %2d: %s (tx: %s)\n", i, to.Hex(), txHashShort)
-			successCount++
+			fmt.Printf("❌ Accounts %d-%d: %v\n", first, last, err)
+			continue
 		}
 
+		fmt.Printf("✅ Accounts %d-%d: %s U2U total (tx: %s)\n",
+			first, last, internal.FormatU2U(total), shortHash(signedTx.Hash()))
+		funded += len(recipients)
+		sent = append(sent, ftx)
 		nonce++
 	}
-	fmt.Printf("\n✅ Successfully funded %d/%d accounts\n", successCount, len(testKeys))
+	return funded, sent
 }
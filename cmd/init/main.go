@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"u2u-tps-benchmark/internal"
+
+	"github.com/unicornultrafoundation/go-u2u/ethclient"
+)
+
+func main() {
+	// Command-line flags
+	rpcURL := flag.String("rpc", "", "RPC endpoint URL to generate the config for (required)")
+	output := flag.String("output", "benchmark_config.json", "Where to write the config")
+	force := flag.Bool("force", false, "Overwrite an existing config file")
+	caCertFile := flag.String("tls-ca-cert", "", "PEM CA certificate to trust for an https endpoint (written as tls_ca_cert_file)")
+	insecure := flag.Bool("tls-insecure-skip-verify", false, "Skip TLS certificate verification (lab nodes only; written as tls_insecure_skip_verify)")
+
+	flag.Parse()
+
+	fmt.Println("╔════════════════════════════════════════╗")
+	fmt.Println("║       U2U Benchmark Config Setup       ║")
+	fmt.Println("╚════════════════════════════════════════╝")
+
+	if *rpcURL == "" {
+		log.Fatal("\n-rpc is required (the endpoint to generate the config for)")
+	}
+	if _, err := os.Stat(*output); err == nil && !*force {
+		log.Fatalf("\n%s already exists; use -force to overwrite it or -output to write elsewhere", *output)
+	}
+
+	// Connect to RPC
+	tlsSettings := &internal.Config{TLSCACertFile: *caCertFile, TLSInsecureSkipVerify: *insecure}
+	tlsConfig, err := tlsSettings.TLSConfig()
+	if err != nil {
+		log.Fatalf("\nInvalid TLS settings: %v", err)
+	}
+	fmt.Printf("🔌 Connecting to RPC: %s\n", *rpcURL)
+	rpcClient, err := internal.DialRPC(context.Background(), *rpcURL, tlsConfig)
+	if err != nil {
+		log.Fatalf("\nFailed to connect to RPC: %v", err)
+	}
+	client := ethclient.NewClient(rpcClient)
+	defer client.Close()
+
+	profile, err := internal.ProfileChain(context.Background(), client, *rpcURL, tlsConfig)
+	if err != nil {
+		log.Fatalf("\nFailed to inspect the chain: %v", err)
+	}
+	profile.Print()
+
+	config := profile.Config()
+	config.TLSCACertFile = *caCertFile
+	config.TLSInsecureSkipVerify = *insecure
+	if err := config.Save(*output); err != nil {
+		log.Fatalf("\nFailed to save config: %v", err)
+	}
+
+	fmt.Printf("\n📝 Config written to %s:\n", *output)
+	fmt.Printf("  %-22s%d\n", "chain_id:", config.ChainID)
+	fmt.Printf("  %-22s%v\n", "eip1559:", config.EIP1559)
+	if config.EIP1559 {
+		fmt.Printf("  %-22s%s (fee cap; remove it to follow the base fee at run time)\n", "fixed_gas_price_wei:", config.FixedGasPriceWei)
+	} else {
+		fmt.Printf("  %-22s%s (the node's suggestion plus 20%%; remove it to ask the node at run time)\n", "fixed_gas_price_wei:", config.FixedGasPriceWei)
+	}
+	fmt.Printf("  %-22s%d\n", "confirmation_poll_ms:", config.ConfirmationPollMs)
+	fmt.Println("\nReview num_accounts, duration_seconds and the workload, then run:")
+	fmt.Printf("  go run cmd/benchmark/main.go -config %s\n", *output)
+}
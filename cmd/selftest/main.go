@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"u2u-tps-benchmark/internal"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+	"github.com/unicornultrafoundation/go-u2u/core/types"
+	"github.com/unicornultrafoundation/go-u2u/crypto"
+	"github.com/unicornultrafoundation/go-u2u/ethclient"
+)
+
+// stage is one line of the final pass/fail checklist
+type stage struct {
+	name   string
+	passed bool
+	ran    bool
+	detail string
+}
+
+func main() {
+	// Command-line flags
+	configFile := flag.String("config", "benchmark_config.json", "Path to config file")
+	rpcURL := flag.String("rpc", "", "RPC endpoint URL (overrides config)")
+	amount := flag.String("amount", "0.01", "Amount to fund each test account (U2U by default, or with a unit)")
+	duration := flag.Int("duration", 5, "Micro-benchmark duration in seconds")
+	timeout := flag.Int("timeout", 60, "Seconds to wait for funding and inclusion")
+	funderKeyFile := flag.String("funder-key-file", "", "File with the funder's hex private key or keystore entry (default: FUNDER_PRIVATE_KEY)")
+	funderKeyPasswordFile := flag.String("funder-key-password-file", "", "File with the keystore passphrase (default: FUNDER_KEY_PASSWORD)")
+
+	flag.Parse()
+
+	fmt.Println("╔════════════════════════════════════════╗")
+	fmt.Println("║           U2U Pipeline Self-Test       ║")
+	fmt.Println("╚════════════════════════════════════════╝")
+
+	// Get funder private key from a file or the environment
+	funderKey, err := internal.LoadFunderKey(*funderKeyFile, *funderKeyPasswordFile)
+	if err != nil {
+		log.Fatalf("\n%v", err)
+	}
+
+	// Load or create config
+	var config *internal.Config
+	if *configFile != "" {
+		config, err = internal.LoadConfig(*configFile)
+		if os.IsNotExist(err) {
+			// If config file doesn't exist, use defaults
+			config = internal.DefaultConfig()
+		} else if err != nil {
+			log.Fatalf("\nFailed to load config: %v", err)
+		}
+	} else {
+		config = internal.DefaultConfig()
+	}
+	if *rpcURL != "" {
+		config.RPCURL = *rpcURL // Flag overrides config
+	}
+
+	amountWei, err := internal.ParseAmount(*amount, "u2u")
+	if err != nil {
+		log.Fatalf("\nInvalid amount: %v", err)
+	}
+
+	stages := []*stage{
+		{name: "Connectivity"},
+		{name: "Funding"},
+		{name: "Signing"},
+		{name: "Submission"},
+		{name: "Inclusion"},
+	}
+	connectivity, funding, signing, submission, inclusion := stages[0], stages[1], stages[2], stages[3], stages[4]
+	waitTimeout := time.Duration(*timeout) * time.Second
+
+	run(config, funderKey, amountWei, *duration, waitTimeout, connectivity, funding, signing, submission, inclusion)
+
+	// Checklist
+	fmt.Println("\n" + strings.Repeat("=", 70))
+	fmt.Println("SELF-TEST RESULTS")
+	fmt.Println(strings.Repeat("=", 70))
+	allPassed := true
+	for _, s := range stages {
+		switch {
+		case !s.ran:
+			fmt.Printf("  ⏭️  %-13s skipped\n", s.name)
+			allPassed = false
+		case s.passed:
+			fmt.Printf("  ✅ %-13s %s\n", s.name, s.detail)
+		default:
+			fmt.Printf("  ❌ %-13s %s\n", s.name, s.detail)
+			allPassed = false
+		}
+	}
+
+	if !allPassed {
+		fmt.Println("\n❌ Self-test failed")
+		os.Exit(1)
+	}
+	fmt.Println("\n✅ Self-test passed: the toolchain works end to end")
+}
+
+// run executes the stages in order and stops at the first failure
+func run(config *internal.Config, funderKey *ecdsa.PrivateKey, amountWei *big.Int, duration int, waitTimeout time.Duration,
+	connectivity, funding, signing, submission, inclusion *stage) {
+	ctx := context.Background()
+	internal.ApplyGoMaxProcs(config)
+
+	// 1. Connectivity
+	fmt.Printf("\n🔌 [1/5] Connecting to RPC: %s\n", config.RPCURL)
+	connectivity.ran = true
+	tlsConfig, err := config.TLSConfig()
+	if err != nil {
+		connectivity.detail = err.Error()
+		return
+	}
+	client, err := internal.CreateOptimizedClient(config.RPCURL, config.GetMaxConnections(), tlsConfig, config.RetryPolicy())
+	if err != nil {
+		connectivity.detail = fmt.Sprintf("failed to connect: %v", err)
+		return
+	}
+	defer client.Close()
+
+	chainID, err := internal.ResolveChainID(ctx, client, config)
+	if err != nil {
+		connectivity.detail = err.Error()
+		return
+	}
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		connectivity.detail = fmt.Sprintf("failed to get block number: %v", err)
+		return
+	}
+	connectivity.passed = true
+	connectivity.detail = fmt.Sprintf("chain ID %s, head block %d", chainID, head)
+
+	// 2. Funding: two fresh accounts funded from FUNDER_PRIVATE_KEY
+	fmt.Printf("\n💸 [2/5] Funding 2 fresh test accounts with %s U2U each...\n", internal.FormatU2U(amountWei))
+	funding.ran = true
+	keys, err := internal.GenerateAccounts(2)
+	if err != nil {
+		funding.detail = fmt.Sprintf("failed to generate keys: %v", err)
+		return
+	}
+	if err := fundAccounts(ctx, client, config, chainID, funderKey, keys, amountWei, waitTimeout); err != nil {
+		funding.detail = err.Error()
+		return
+	}
+	funding.passed = true
+	funding.detail = fmt.Sprintf("2 accounts funded from %s", crypto.PubkeyToAddress(funderKey.PublicKey).Hex())
+
+	// 3. Signing: sign with a test key and recover the sender
+	fmt.Printf("\n✍️  [3/5] Checking transaction signing...\n")
+	signing.ran = true
+	from := crypto.PubkeyToAddress(keys[0].PublicKey)
+	probe := types.NewTransaction(0, from, big.NewInt(0), 21000, big.NewInt(1), nil)
+	signed, err := internal.SignTransaction(probe, chainID, keys[0])
+	if err != nil {
+		signing.detail = fmt.Sprintf("failed to sign: %v", err)
+		return
+	}
+	recovered, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	if err != nil || recovered != from {
+		signing.detail = fmt.Sprintf("recovered sender %s does not match %s (%v)", recovered.Hex(), from.Hex(), err)
+		return
+	}
+	signing.passed = true
+	signing.detail = "signature recovers the expected sender"
+
+	// 4. Submission: a short micro-benchmark between the two test accounts
+	fmt.Printf("\n🚀 [4/5] Running a %ds micro-benchmark...\n", duration)
+	submission.ran = true
+	testConfig := *config
+	testConfig.NumAccounts = len(keys)
+	testConfig.DurationSeconds = duration
+	testConfig.SoakMode = false
+	testConfig.UntilInterrupt = false
+	testConfig.WarmupDuration = 0
+	testConfig.TotalTxLimit = 0
+	testConfig.Workload = internal.WorkloadTransfer
+	testConfig.TransferPattern = internal.PatternRoundRobin
+	testConfig.TransferAmount = "0"
+	testConfig.TrackConfirmations = true
+	testConfig.DrainTimeout = int(waitTimeout.Seconds())
+	testConfig.OutputFile = filepath.Join(os.TempDir(), "u2u_selftest_results.json")
+	testConfig.TxHashLogFile = ""
+	testConfig.ClaimDir = ""
+	testConfig.WorkloadMix = nil
+	testConfig.TPSSchedule = nil
+	testConfig.TargetBlockUtilization = 0
+	testConfig.GasPriceMultipliers = nil
+	testConfig.ActivateAccounts = false
+	testConfig.FairNonce = false
+	testConfig.RemoteSignerURL = ""
+	testConfig.RPCURLs = nil
+	testConfig.NonceReconcile = ""
+	testConfig.MaxSpend = ""
+	testConfig.MaxWorkers = 0
+	testConfig.StopOnStability = false
+
+	accounts, err := internal.InitializeAccounts(client, keys, &testConfig)
+	if err != nil {
+		submission.detail = fmt.Sprintf("failed to initialize accounts: %v", err)
+		return
+	}
+	benchmark, err := internal.NewBenchmark(&testConfig, client, accounts)
+	if err != nil {
+		submission.detail = fmt.Sprintf("failed to create benchmark: %v", err)
+		return
+	}
+	benchmark.Start()
+
+	results := benchmark.Results()
+	if results == nil || results.TotalSubmitted == 0 {
+		submission.detail = "no transaction was accepted by the RPC"
+		return
+	}
+	submission.passed = true
+	submission.detail = fmt.Sprintf("%d transactions submitted (%.1f TPS)", results.TotalSubmitted, results.AvgSubmittedTPS)
+
+	// 5. Inclusion: at least one benchmark transaction made it into a block
+	inclusion.ran = true
+	confirmed := results.TotalConfirmed + results.DrainConfirmed
+	if confirmed == 0 {
+		inclusion.detail = fmt.Sprintf("none of %d submitted transactions was included within %v", results.TotalSubmitted, waitTimeout)
+		return
+	}
+	inclusion.passed = true
+	inclusion.detail = fmt.Sprintf("%d of %d transactions included", confirmed, results.TotalSubmitted)
+}
+
+// fundAccounts sends amountWei to every key and waits until all funding transactions are mined
+func fundAccounts(ctx context.Context, client *ethclient.Client, config *internal.Config, chainID *big.Int,
+	funderKey *ecdsa.PrivateKey, keys []*ecdsa.PrivateKey, amountWei *big.Int, waitTimeout time.Duration) error {
+	funderAddr := crypto.PubkeyToAddress(funderKey.PublicKey)
+
+	gas, err := internal.ResolveGasSettings(ctx, client, config.FixedGasPriceWei, config.EIP1559, config.Tip())
+	if err != nil {
+		return fmt.Errorf("failed to resolve gas price: %v", err)
+	}
+	nonce, err := client.PendingNonceAt(ctx, funderAddr)
+	if err != nil {
+		return fmt.Errorf("failed to get funder nonce: %v", err)
+	}
+
+	var hashes []common.Hash
+	for i, key := range keys {
+		to := crypto.PubkeyToAddress(key.PublicKey)
+		tx := gas.NewTx(chainID, nonce, to, amountWei, 21000, nil)
+		signedTx, err := internal.SignTransaction(tx, chainID, funderKey)
+		if err != nil {
+			return fmt.Errorf("failed to sign funding tx %d: %v", i, err)
+		}
+		if err := client.SendTransaction(ctx, signedTx); err != nil {
+			return fmt.Errorf("failed to send funding tx %d: %v", i, err)
+		}
+		fmt.Printf("   Funding %s (tx: %s)\n", to.Hex(), signedTx.Hash().Hex())
+		hashes = append(hashes, signedTx.Hash())
+		nonce++
+	}
+
+	deadline := time.Now().Add(waitTimeout)
+	for _, hash := range hashes {
+		for {
+			receipt, err := client.TransactionReceipt(ctx, hash)
+			if err == nil {
+				if receipt.Status != types.ReceiptStatusSuccessful {
+					return fmt.Errorf("funding tx %s reverted", hash.Hex())
+				}
+				break
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("funding tx %s not mined within %v", hash.Hex(), waitTimeout)
+			}
+			time.Sleep(time.Second)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"u2u-tps-benchmark/internal"
+)
+
+func main() {
+	keysFile := flag.String("keys", "test_keys.json", "Path to the private keys file to split")
+	shards := flag.Int("shards", 2, "Number of shards (one per load-generator machine)")
+	index := flag.Int("index", -1, "Shard to write (0-based, -1 = write all shards)")
+	output := flag.String("output", "", "Output file (single shard only, default: <keys>_shard<index>.json)")
+	overwrite := flag.Bool("overwrite", false, "Overwrite output files if they already exist")
+
+	flag.Parse()
+
+	fmt.Println("╔════════════════════════════════════════╗")
+	fmt.Println("║            U2U Key Sharding            ║")
+	fmt.Println("╚════════════════════════════════════════╝")
+
+	if *shards <= 0 {
+		log.Fatalf("\nNumber of shards must be greater than zero")
+	}
+	if *index < -1 || *index >= *shards {
+		log.Fatalf("\nShard index must be between 0 and %d (or -1 for all)", *shards-1)
+	}
+	if *output != "" && *index == -1 {
+		log.Fatalf("\n-output can only be used together with -index")
+	}
+
+	keys, err := internal.LoadPrivateKeys(*keysFile)
+	if err != nil {
+		log.Fatalf("\nFailed to load private keys: %v", err)
+	}
+	if len(keys) < *shards {
+		log.Fatalf("\nCannot split %d keys into %d shards", len(keys), *shards)
+	}
+
+	first, last := *index, *index
+	if *index == -1 {
+		first, last = 0, *shards-1
+	}
+
+	fmt.Printf("\n✂️  Splitting %d keys into %d shards\n", len(keys), *shards)
+	for i := first; i <= last; i++ {
+		path := *output
+		if path == "" {
+			path = shardFileName(*keysFile, i)
+		}
+
+		if !*overwrite {
+			if _, err := os.Stat(path); err == nil {
+				log.Fatalf("\nOutput file %s already exists. Use -overwrite to replace it.", path)
+			}
+		}
+
+		start, end := internal.ShardRange(len(keys), *shards, i)
+		if err := internal.SavePrivateKeys(keys[start:end], path); err != nil {
+			log.Fatalf("\nFailed to save shard %d: %v", i, err)
+		}
+		fmt.Printf("✅ Shard %d: keys %d-%d (%d accounts) → %s\n", i, start, end-1, end-start, path)
+	}
+
+	fmt.Println("\n⚠️  Give each load generator a different shard; never run two processes on the same shard.")
+}
+
+// shardFileName derives "<base>_shard<i>.json" from the input keys file
+func shardFileName(keysFile string, index int) string {
+	ext := filepath.Ext(keysFile)
+	return fmt.Sprintf("%s_shard%d%s", strings.TrimSuffix(keysFile, ext), index, ext)
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"u2u-tps-benchmark/internal"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+	"github.com/unicornultrafoundation/go-u2u/core/types"
+	"github.com/unicornultrafoundation/go-u2u/ethclient"
+)
+
+func main() {
+	// Command-line flags
+	configFile := flag.String("config", "benchmark_config.json", "Path to config file")
+	rpcURL := flag.String("rpc", "", "RPC endpoint URL (overrides config)")
+	hashesFile := flag.String("hashes", "", "Path to tx hash log file (overrides config tx_hash_log_file)")
+	workers := flag.Int("workers", 16, "Number of concurrent receipt lookups")
+
+	flag.Parse()
+
+	fmt.Println("╔════════════════════════════════════════╗")
+	fmt.Println("║       U2U Transaction Verification     ║")
+	fmt.Println("╚════════════════════════════════════════╝")
+
+	// Load or create config
+	var config *internal.Config
+	var err error
+
+	if *configFile != "" {
+		config, err = internal.LoadConfig(*configFile)
+		if os.IsNotExist(err) {
+			// If config file doesn't exist, use defaults
+			config = internal.DefaultConfig()
+		} else if err != nil {
+			log.Fatalf("\nFailed to load config: %v", err)
+		}
+	} else {
+		config = internal.DefaultConfig()
+	}
+
+	// Use config values, but allow flags to override
+	rpcEndpoint := config.RPCURL
+	if *rpcURL != "" {
+		rpcEndpoint = *rpcURL // Flag overrides config
+	}
+
+	hashesFilePath := config.TxHashLogFile
+	if *hashesFile != "" {
+		hashesFilePath = *hashesFile // Flag overrides config
+	}
+	if hashesFilePath == "" {
+		log.Fatal("\nNo hash log file given. Use -hashes or set tx_hash_log_file in the config")
+	}
+
+	if *workers <= 0 {
+		*workers = 1
+	}
+
+	// Load hashes
+	hashes, err := internal.LoadTxHashes(hashesFilePath)
+	if err != nil {
+		log.Fatalf("\nFailed to load tx hashes: %v", err)
+	}
+	fmt.Printf("📄 Loaded %d transaction hashes from %s\n", len(hashes), hashesFilePath)
+
+	// Connect to RPC
+	fmt.Printf("🔌 Connecting to RPC: %s\n", rpcEndpoint)
+	tlsConfig, err := config.TLSConfig()
+	if err != nil {
+		log.Fatalf("\nInvalid TLS settings: %v", err)
+	}
+	rpcClient, err := internal.DialRPC(context.Background(), rpcEndpoint, tlsConfig)
+	if err != nil {
+		log.Fatalf("\nFailed to connect to RPC: %v", err)
+	}
+	client := ethclient.NewClient(rpcClient)
+	defer client.Close()
+
+	// Verify connection
+	chainID, err := internal.FetchChainID(context.Background(), client, config.GetStartupAttempts())
+	if err != nil {
+		log.Fatalf("\nFailed to get chain ID: %v", err)
+	}
+	fmt.Printf("✅ Connected to chain ID: %s\n\n", chainID.String())
+
+	fmt.Printf("🔍 Fetching receipts with %d workers...\n", *workers)
+
+	ctx := context.Background()
+	jobs := make(chan common.Hash)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	succeeded := 0
+	reverted := 0
+	missing := 0
+	lookupErrors := 0
+	totalGasUsed := uint64(0)
+	var firstLookupError error
+
+	for w := 0; w < *workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for hash := range jobs {
+				receipt, err := client.TransactionReceipt(ctx, hash)
+
+				mu.Lock()
+				switch {
+				case err != nil:
+					// "not found" means the tx never made it into a block
+					missing++
+					if !strings.Contains(strings.ToLower(err.Error()), "not found") {
+						lookupErrors++
+						if firstLookupError == nil {
+							firstLookupError = err
+						}
+					}
+				case receipt.Status == types.ReceiptStatusSuccessful:
+					succeeded++
+					totalGasUsed += receipt.GasUsed
+				default:
+					reverted++
+					totalGasUsed += receipt.GasUsed
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, hash := range hashes {
+		jobs <- hash
+	}
+	close(jobs)
+	wg.Wait()
+
+	included := succeeded + reverted
+	inclusionRate := 0.0
+	if len(hashes) > 0 {
+		inclusionRate = float64(included) / float64(len(hashes)) * 100
+	}
+
+	fmt.Println("\n" + strings.Repeat("=", 70))
+	fmt.Printf("📊 Summary:\n")
+	fmt.Printf("  Total Hashes:       %d\n", len(hashes))
+	fmt.Printf("  Succeeded:          %d\n", succeeded)
+	fmt.Printf("  Reverted:           %d\n", reverted)
+	fmt.Printf("  Missing:            %d\n", missing)
+	fmt.Printf("  Inclusion Rate:     %.2f%%\n", inclusionRate)
+	fmt.Printf("  Total Gas Used:     %d\n", totalGasUsed)
+	if lookupErrors > 0 {
+		fmt.Printf("\n⚠️  %d lookups failed with RPC errors and were counted as missing\n", lookupErrors)
+		fmt.Printf("   First error: %v\n", firstLookupError)
+	}
+	fmt.Println(strings.Repeat("=", 70))
+}
@@ -22,14 +22,31 @@ import (
 
 type AccountSender struct {
 	client     *ethclient.Client
-	privateKey *ecdsa.PrivateKey
+	privateKey *ecdsa.PrivateKey // nil when a remote signer holds the key
+	signer     *RemoteSigner     // Signs instead of privateKey (nil unless remote_signer_url is set)
 	from       common.Address
 	chainID    *big.Int
-	nonce      uint64 // Atomic nonce counter (use atomic operations only!)
+	nonce      uint64          // Atomic nonce counter (use atomic operations only!)
+	turns      *nonceTurnstile // Orders dispatch by nonce (nil unless fair_nonce is set)
 
 	// Statistics per account (atomic)
-	sent   uint64
-	errors uint64
+	sent     uint64
+	errors   uint64
+	received uint64 // Transfers sent to this account by the benchmark
+	latency  int64  // Cumulative latency of successful sends (nanoseconds)
+
+	// Nonce drift recovery (atomic)
+	nonceErrorStreak uint64 // Consecutive nonce errors since the last success or resync
+	lastResync       int64  // Unix nanoseconds of the last queued resync
+
+	// Earliest start of the next send with min_account_interval_ms (Unix nanoseconds, atomic)
+	nextSlot int64
+
+	// Index into Config.Endpoints() of the RPC endpoint the account is pinned to
+	endpoint int
+
+	// Position in the loaded keys (or signer accounts), kept when underfunded accounts are dropped
+	keyIndex int
 }
 
 type KeyStore struct {
@@ -37,8 +54,10 @@ type KeyStore struct {
 }
 
 // CreateOptimizedClient creates an ethclient with optimized HTTP connection pooling
-// This allows thousands of concurrent requests without connection overhead
-func CreateOptimizedClient(rpcURL string, maxConnections int) (*ethclient.Client, error) {
+// This allows thousands of concurrent requests without connection overhead.
+// tlsConfig is used for https endpoints (nil = default verification, see Config.TLSConfig);
+// retry adds transport-level retries and circuit breaking (nil = off, see Config.RetryPolicy).
+func CreateOptimizedClient(rpcURL string, maxConnections int, tlsConfig *tls.Config, retry *RetryPolicy) (*ethclient.Client, error) {
 	// Create aggressive HTTP transport for high throughput
 	// Force HTTP/1.1 by setting TLSNextProto to empty map to avoid HTTP/2 GOAWAY errors
 	transport := &http.Transport{
@@ -52,11 +71,23 @@ func CreateOptimizedClient(rpcURL string, maxConnections int) (*ethclient.Client
 		ExpectContinueTimeout: 500 * time.Millisecond, // Faster expect-continue
 		ResponseHeaderTimeout: 5 * time.Second,        // Don't wait forever for headers
 		// Disable HTTP/2 by setting TLSNextProto to empty map (forces HTTP/1.1)
-		TLSNextProto: make(map[string]func(authority string, c *tls.Conn) http.RoundTripper),
+		TLSNextProto:    make(map[string]func(authority string, c *tls.Conn) http.RoundTripper),
+		TLSClientConfig: tlsConfig,
+	}
+
+	// Retry transient failures below the RPC client, so workers only see what retries couldn't fix
+	var next http.RoundTripper = transport
+	var retrying *retryTransport
+	if retry != nil {
+		retrying = newRetryTransport(transport, *retry)
+		next = retrying
 	}
 
+	// Count in-flight requests so the report can tell whether the pool was the bottleneck
+	monitor := &poolMonitor{next: next, limit: maxConnections}
+
 	httpClient := &http.Client{
-		Transport: transport,
+		Transport: monitor,
 		Timeout:   10 * time.Second, // Aggressive timeout for fast failure
 	}
 
@@ -68,6 +99,10 @@ func CreateOptimizedClient(rpcURL string, maxConnections int) (*ethclient.Client
 
 	// Wrap with ethclient
 	client := ethclient.NewClient(rpcClient)
+	poolMonitors.Store(client, monitor)
+	if retrying != nil {
+		retryTransports.Store(client, retrying)
+	}
 	return client, nil
 }
 
@@ -112,19 +147,22 @@ func SavePrivateKeys(keys []*ecdsa.PrivateKey, filename string) error {
 	return encoder.Encode(keyStore)
 }
 
-// LoadPrivateKeys loads keys from file
+// LoadPrivateKeys loads keys from file.
+// Accepts the {"private_keys": [...]} JSON format or plain text with one hex key
+// per line (blank lines and lines starting with # are ignored).
 func LoadPrivateKeys(filename string) ([]*ecdsa.PrivateKey, error) {
-	file, err := os.Open(filename)
+	data, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
 
 	var keyStore KeyStore
-	decoder := json.NewDecoder(file)
-	err = decoder.Decode(&keyStore)
-	if err != nil {
-		return nil, err
+	if err := json.Unmarshal(data, &keyStore); err != nil {
+		// Not JSON: fall back to one key per line
+		keyStore.Keys = parseKeyLines(string(data))
+		if len(keyStore.Keys) == 0 {
+			return nil, fmt.Errorf("no keys found (file is neither JSON nor one hex key per line)")
+		}
 	}
 
 	keys := make([]*ecdsa.PrivateKey, len(keyStore.Keys))
@@ -145,21 +183,81 @@ func LoadPrivateKeys(filename string) ([]*ecdsa.PrivateKey, error) {
 	return keys, nil
 }
 
-// InitializeAccounts creates AccountSender instances
-func InitializeAccounts(client *ethclient.Client, privateKeys []*ecdsa.PrivateKey) ([]*AccountSender, error) {
-	ctx := context.Background()
+// parseKeyLines extracts hex keys from newline-delimited text
+func parseKeyLines(text string) []string {
+	var keys []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		keys = append(keys, line)
+	}
+	return keys
+}
 
-	chainID, err := client.ChainID(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get chain ID: %v", err)
+// ResolveChainID returns the chain ID used for signing. A configured chain_id overrides
+// the node's value; it is still checked against the node when the node answers.
+// Without a configured chain_id the request is retried (startup_attempts).
+func ResolveChainID(ctx context.Context, client *ethclient.Client, config *Config) (*big.Int, error) {
+	attempts := config.GetStartupAttempts()
+	if config.ChainID > 0 {
+		attempts = 1
+	}
+	nodeChainID, err := FetchChainID(ctx, client, attempts)
+	if config.ChainID <= 0 {
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chain ID: %v", err)
+		}
+		return nodeChainID, nil
 	}
 
-	fmt.Printf("Initializing %d accounts...\n", len(privateKeys))
-	accounts := make([]*AccountSender, len(privateKeys))
+	chainID := big.NewInt(config.ChainID)
+	if err != nil {
+		fmt.Printf("⚠️  Could not get chain ID from node (%v), using configured chain ID %s\n", err, chainID)
+	} else if nodeChainID.Cmp(chainID) != 0 {
+		fmt.Printf("⚠️  Configured chain ID %s does not match the node's chain ID %s; transactions will be signed for %s\n",
+			chainID, nodeChainID, chainID)
+	}
+	return chainID, nil
+}
 
+// InitializeAccounts creates AccountSender instances.
+// config.NonceOffset is added to each fetched nonce (for nonce-gap testing).
+func InitializeAccounts(client *ethclient.Client, privateKeys []*ecdsa.PrivateKey, config *Config) ([]*AccountSender, error) {
+	addresses := make([]common.Address, len(privateKeys))
 	for i, key := range privateKeys {
-		from := crypto.PubkeyToAddress(key.PublicKey)
+		addresses[i] = crypto.PubkeyToAddress(key.PublicKey)
+	}
+
+	accounts, err := initializeSenders(context.Background(), client, addresses, config)
+	if err != nil {
+		return nil, err
+	}
+	for i, account := range accounts {
+		account.privateKey = privateKeys[i]
+	}
+	return accounts, nil
+}
+
+// initializeSenders reads nonce, balance and code of every sender address.
+// The caller attaches the key (or remote signer) that signs for each account.
+func initializeSenders(ctx context.Context, client *ethclient.Client, addresses []common.Address, config *Config) ([]*AccountSender, error) {
+	chainID, err := ResolveChainID(ctx, client, config)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Initializing %d accounts...\n", len(addresses))
+	if config.NonceOffset != 0 {
+		fmt.Printf("⚠️  Applying nonce offset %+d to every account\n", config.NonceOffset)
+	}
+	if config.NoPreflight {
+		return presetSenders(client, chainID, addresses, config), nil
+	}
+	accounts := make([]*AccountSender, len(addresses))
 
+	for i, from := range addresses {
 		//Get current nonce
 		nonce, err := client.PendingNonceAt(ctx, from)
 		if err != nil {
@@ -172,12 +270,27 @@ func InitializeAccounts(client *ethclient.Client, privateKeys []*ecdsa.PrivateKe
 			return nil, fmt.Errorf("failed to get balance for account %d: %v", i, err)
 		}
 
+		// Senders must be plain accounts; code at the address means a wrong key set
+		code, err := client.CodeAt(ctx, from, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get code for account %d: %v", i, err)
+		}
+		if len(code) > 0 {
+			if config.FailOnContractSenders {
+				return nil, fmt.Errorf("account %d (%s) is a contract (%d bytes of code)", i, from.Hex(), len(code))
+			}
+			fmt.Printf("⚠️  Account %d (%s) has contract code (%d bytes); transactions from it may fail\n",
+				i, from.Hex(), len(code))
+		}
+
+		nonce = applyNonceOffset(nonce, config.NonceOffset)
+
 		accounts[i] = &AccountSender{
-			client:     client,
-			privateKey: key,
-			from:       from,
-			chainID:    chainID,
-			nonce:      nonce,
+			client:   client,
+			from:     from,
+			chainID:  chainID,
+			nonce:    nonce,
+			keyIndex: i,
 		}
 
 		balanceEth := new(big.Float).Quo(
@@ -190,7 +303,7 @@ func InitializeAccounts(client *ethclient.Client, privateKeys []*ecdsa.PrivateKe
 
 		// Small delay to avoid overwhelming RPC during initialization
 		// Only add delay every 10 accounts to balance speed vs stability
-		if (i+1)%10 == 0 && i < len(privateKeys)-1 {
+		if (i+1)%10 == 0 && i < len(addresses)-1 {
 			time.Sleep(50 * time.Millisecond)
 		}
 	}
@@ -198,41 +311,133 @@ func InitializeAccounts(client *ethclient.Client, privateKeys []*ecdsa.PrivateKe
 	return accounts, nil
 }
 
-// CheckBalances verifies all accounts have sufficient balance
-func CheckBalances(client *ethclient.Client, accounts []*AccountSender, minBalance *big.Int) error {
+// presetSenders builds the senders without asking the node (no_preflight): every account
+// starts at start_nonce plus nonce_offset and its balance and code are not checked
+func presetSenders(client *ethclient.Client, chainID *big.Int, addresses []common.Address, config *Config) []*AccountSender {
+	nonce := applyNonceOffset(config.StartNonce, config.NonceOffset)
+	fmt.Printf("⚠️  Preflight disabled: every account starts at nonce %d, balances are not read\n", nonce)
+	accounts := make([]*AccountSender, len(addresses))
+	for i, from := range addresses {
+		accounts[i] = &AccountSender{
+			client:   client,
+			from:     from,
+			chainID:  chainID,
+			nonce:    nonce,
+			keyIndex: i,
+		}
+	}
+	return accounts
+}
+
+// applyNonceOffset adds the configured offset to a nonce (never below zero)
+func applyNonceOffset(nonce uint64, offset int) uint64 {
+	if offset < 0 && uint64(-offset) > nonce {
+		return 0
+	}
+	return uint64(int64(nonce) + int64(offset))
+}
+
+// MinimumBalance returns the balance each account needs before a run.
+// MinBalanceWei wins when set; otherwise the cost of MinBalanceTxCount transfers
+// (value + gas) at the given gas price is used, so zero-value workloads only
+// need to cover gas.
+func MinimumBalance(config *Config, gasPrice *big.Int) (*big.Int, error) {
+	if config.MinBalanceWei != "" {
+		minBalance, ok := new(big.Int).SetString(config.MinBalanceWei, 10)
+		if !ok || minBalance.Sign() < 0 {
+			return nil, fmt.Errorf("invalid min_balance_wei: %q", config.MinBalanceWei)
+		}
+		return minBalance, nil
+	}
+
+	transferValue, err := config.TxValue()
+	if err != nil {
+		return nil, err
+	}
+
+	txCount := config.MinBalanceTxCount
+	if txCount <= 0 {
+		txCount = 50
+	}
+
+	// Cost of one transfer = value + gasLimit * gasPrice (largest jittered gas limit)
+	// Budget for the most expensive fee group
+	maxMultiplier := 1.0
+	for _, m := range config.GasPriceMultipliers {
+		if m > maxMultiplier {
+			maxMultiplier = m
+		}
+	}
+	if maxMultiplier > 1 {
+		gasPrice = scaleWei(gasPrice, maxMultiplier)
+	}
+	txCost := new(big.Int).Mul(new(big.Int).SetUint64(config.MaxGasLimit()), gasPrice)
+	txCost.Add(txCost, transferValue)
+
+	return txCost.Mul(txCost, big.NewInt(int64(txCount))), nil
+}
+
+// DescribeMinimumBalance explains what MinimumBalance is budgeting for
+func DescribeMinimumBalance(config *Config) string {
+	if config.MinBalanceWei != "" {
+		return "fixed by min_balance_wei"
+	}
+	txCount := config.MinBalanceTxCount
+	if txCount <= 0 {
+		txCount = 50
+	}
+	if value, err := config.TxValue(); err == nil && value.Sign() == 0 {
+		return fmt.Sprintf("gas only for %d zero-value txs", txCount)
+	}
+	return fmt.Sprintf("%d × (transfer value + gas)", txCount)
+}
+
+// CheckBalances verifies all accounts have sufficient balance and returns the accounts to use.
+// With skipUnderfunded, underfunded accounts are dropped instead of aborting the run.
+func CheckBalances(client *ethclient.Client, accounts []*AccountSender, minBalance *big.Int, skipUnderfunded bool) ([]*AccountSender, error) {
 	ctx := context.Background()
 
 	fmt.Printf("\nChecking account balances...\n")
-	insufficientFunds := false
+	funded := make([]*AccountSender, 0, len(accounts))
 
 	for i, account := range accounts {
 		balance, err := client.BalanceAt(ctx, account.from, nil)
 		if err != nil {
-			return fmt.Errorf("failed to check balance for account %d: %v", i, err)
+			return nil, fmt.Errorf("failed to check balance for account %d: %v", i, err)
 		}
 
 		if balance.Cmp(minBalance) < 0 {
-			balanceEth := new(big.Float).Quo(
-				new(big.Float).SetInt(balance),
-				new(big.Float).SetInt(big.NewInt(1e18)),
-			)
-			minEth := new(big.Float).Quo(
-				new(big.Float).SetInt(minBalance),
-				new(big.Float).SetInt(big.NewInt(1e18)),
-			)
-
-			fmt.Printf("⚠️  Account %d has insufficient balance: %.6f U2U (need %.6f U2U)\n",
-				i, balanceEth, minEth)
-			insufficientFunds = true
+			fmt.Printf("⚠️  Account %d (%s) has insufficient balance: %s U2U (need %s U2U)\n",
+				i, account.from.Hex(), FormatU2U(balance), FormatU2U(minBalance))
+			continue
 		}
+		funded = append(funded, account)
 	}
 
-	if insufficientFunds {
-		return fmt.Errorf("some accounts have insufficient balance")
+	skipped := len(accounts) - len(funded)
+	if skipped == 0 {
+		fmt.Println("✅ All accounts have sufficient balance")
+		return funded, nil
 	}
 
-	fmt.Println("✅ All accounts have sufficient balance")
-	return nil
+	if !skipUnderfunded {
+		return nil, fmt.Errorf("some accounts have insufficient balance")
+	}
+	if len(funded) == 0 {
+		return nil, fmt.Errorf("no account has sufficient balance")
+	}
+
+	fmt.Printf("⚠️  Skipping %d underfunded accounts, continuing with %d\n", skipped, len(funded))
+	return funded, nil
+}
+
+// AvgLatency returns the average latency of this account's successful sends
+func (a *AccountSender) AvgLatency() time.Duration {
+	sent := atomic.LoadUint64(&a.sent)
+	if sent == 0 {
+		return 0
+	}
+	return time.Duration(atomic.LoadInt64(&a.latency) / int64(sent))
 }
 
 // GetNextNonce atomically gets and increments the nonce (lock-free)
@@ -254,7 +459,7 @@ func (a *AccountSender) ResyncNonce(ctx context.Context) error {
 	}
 
 	// Atomically store the new nonce
-	atomic.StoreUint64(&a.nonce, nonce)
+	a.SetNonce(nonce)
 	return nil
 }
 
@@ -267,3 +472,31 @@ func (a *AccountSender) From() common.Address {
 func (a *AccountSender) CurrentNonce() uint64 {
 	return atomic.LoadUint64(&a.nonce)
 }
+
+// PeekNextNonce is an alias of CurrentNonce, kept for tooling that reads the next nonce under this name
+func (a *AccountSender) PeekNextNonce() uint64 {
+	return a.CurrentNonce()
+}
+
+// SetNonce atomically replaces the local nonce (for tests and gap-repair tooling).
+// Workers sending concurrently will continue from the new value.
+func (a *AccountSender) SetNonce(nonce uint64) {
+	atomic.StoreUint64(&a.nonce, nonce)
+	if a.turns != nil {
+		a.turns.reset(nonce)
+	}
+}
+
+// ShardRange returns the [start, end) slice of keys assigned to shard index out of shards.
+// Shards are contiguous and disjoint; the first total%shards shards get one extra key.
+func ShardRange(total, shards, index int) (start, end int) {
+	size := total / shards
+	extra := total % shards
+
+	start = index*size + min(index, extra)
+	end = start + size
+	if index < extra {
+		end++
+	}
+	return start, end
+}
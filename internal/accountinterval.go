@@ -0,0 +1,37 @@
+package internal
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// awaitInterval spaces this account's sends at least interval apart (min_account_interval_ms).
+// The account's workers reserve consecutive slots with a CAS, so the spacing holds across all of
+// them while other accounts keep sending. Returns false if stop closes while waiting.
+func (a *AccountSender) awaitInterval(interval time.Duration, stop <-chan struct{}) bool {
+	var slot int64
+	for {
+		now := time.Now().UnixNano()
+		next := atomic.LoadInt64(&a.nextSlot)
+		slot = next
+		if slot < now {
+			slot = now
+		}
+		if atomic.CompareAndSwapInt64(&a.nextSlot, next, slot+int64(interval)) {
+			break
+		}
+	}
+
+	wait := time.Until(time.Unix(0, slot))
+	if wait <= 0 {
+		return true
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-stop:
+		return false
+	}
+}
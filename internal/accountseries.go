@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// AccountSeries is the per-interval history of one sender account
+type AccountSeries struct {
+	AccountID int      `json:"account_id"`
+	Address   string   `json:"address"`
+	Sent      []uint64 `json:"sent"`   // Submitted in each interval
+	Errors    []uint64 `json:"errors"` // Failed sends in each interval
+}
+
+// AccountSeriesFile is the layout of the per_account_time_series output file
+type AccountSeriesFile struct {
+	RunLabel              string          `json:"run_label,omitempty"`
+	ReportIntervalSeconds int             `json:"report_interval_seconds"`
+	Accounts              []AccountSeries `json:"accounts"`
+}
+
+// accountSeries records per-account sent/error deltas at every report interval.
+// Only the metrics reporter touches it while the run is going.
+type accountSeries struct {
+	lastSent   []uint64
+	lastErrors []uint64
+	sent       [][]uint64 // [account][interval]
+	errors     [][]uint64
+}
+
+func newAccountSeries(accounts int) *accountSeries {
+	s := &accountSeries{
+		lastSent:   make([]uint64, accounts),
+		lastErrors: make([]uint64, accounts),
+		sent:       make([][]uint64, accounts),
+		errors:     make([][]uint64, accounts),
+	}
+	for i := 0; i < accounts; i++ {
+		s.sent[i], s.errors[i] = []uint64{}, []uint64{}
+	}
+	return s
+}
+
+// Record appends one interval for every account
+func (s *accountSeries) Record(accounts []*AccountSender) {
+	for i, account := range accounts {
+		sent := atomic.LoadUint64(&account.sent)
+		errors := atomic.LoadUint64(&account.errors)
+		s.sent[i] = append(s.sent[i], counterDelta(sent, s.lastSent[i]))
+		s.errors[i] = append(s.errors[i], counterDelta(errors, s.lastErrors[i]))
+		s.lastSent[i], s.lastErrors[i] = sent, errors
+	}
+}
+
+// counterDelta is cur-last, or cur when the counter was reset in between
+func counterDelta(cur, last uint64) uint64 {
+	if cur < last {
+		return cur
+	}
+	return cur - last
+}
+
+// accountSeriesFilename derives the time series file from output_file:
+// benchmark_results.json -> benchmark_results_accounts.json (always JSON)
+func accountSeriesFilename(outputFile string) string {
+	return strings.TrimSuffix(outputFile, filepath.Ext(outputFile)) + "_accounts.json"
+}
+
+// saveAccountSeries writes the per-account time series next to the results file
+func (b *Benchmark) saveAccountSeries() {
+	if b.accountSeries == nil {
+		return
+	}
+	out := AccountSeriesFile{
+		RunLabel:              b.config.RunLabel,
+		ReportIntervalSeconds: b.config.ReportInterval,
+		Accounts:              make([]AccountSeries, 0, len(b.accounts)),
+	}
+	for i, account := range b.accounts {
+		out.Accounts = append(out.Accounts, AccountSeries{
+			AccountID: i,
+			Address:   account.from.Hex(),
+			Sent:      b.accountSeries.sent[i],
+			Errors:    b.accountSeries.errors[i],
+		})
+	}
+
+	filename := accountSeriesFilename(b.config.OutputFile)
+	data, err := json.Marshal(out)
+	if err == nil {
+		err = os.WriteFile(filename, data, 0644)
+	}
+	if err != nil {
+		fmt.Printf("⚠️  Failed to save per-account time series: %v\n", err)
+		return
+	}
+	fmt.Printf("📝 Per-account time series saved to %s\n", filename)
+}
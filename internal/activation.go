@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+)
+
+// activationStats records the one-off activation transactions sent before measurement
+type activationStats struct {
+	sent      int
+	confirmed int
+	failed    int // Could not be signed or sent
+	duration  time.Duration
+}
+
+// activateAccounts sends one zero-value self-transfer per account and waits for them
+// to be mined, so first-transaction overhead on fresh accounts (state creation, cold
+// caches on the node) happens before the measured window rather than inside it.
+func (b *Benchmark) activateAccounts() {
+	if !b.config.ActivateAccounts || b.config.IsReadWorkload() {
+		return
+	}
+
+	timeout := time.Duration(b.config.ActivationTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	fmt.Printf("\n🔑 Activating %d accounts (one self-transfer each, excluded from metrics)...\n", len(b.accounts))
+	start := time.Now()
+	ctx := context.Background()
+	stats := &activationStats{}
+
+	hashes := make([]common.Hash, len(b.accounts))
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for i, account := range b.accounts {
+		wg.Add(1)
+		go func(i int, account *AccountSender) {
+			defer wg.Done()
+
+			nonce := account.GetNextNonce()
+			tx := b.gasFor(i).NewTx(account.chainID, nonce, account.from, new(big.Int), intrinsicTransferGas, nil)
+			signedTx, err := account.Sign(ctx, tx)
+			account.awaitTurn(nonce) // Pass the fair_nonce turnstile, or the workers wait on this nonce forever
+			if err == nil {
+				err = account.client.SendTransaction(ctx, signedTx)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				// The nonce was not used; hand it back so the workers don't leave a gap
+				account.SetNonce(nonce)
+				fmt.Printf("⚠️  Account %d: activation failed: %v\n", i, err)
+				stats.failed++
+				return
+			}
+			hashes[i] = signedTx.Hash()
+			stats.sent++
+		}(i, account)
+	}
+	wg.Wait()
+
+	// Wait for every activation to be mined (or the timeout)
+	deadline := time.Now().Add(timeout)
+	for {
+		for i, hash := range hashes {
+			if hash == (common.Hash{}) {
+				continue
+			}
+			if _, err := b.accounts[i].client.TransactionReceipt(ctx, hash); err == nil {
+				stats.confirmed++
+				hashes[i] = common.Hash{}
+			}
+		}
+		if stats.confirmed == stats.sent || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	stats.duration = time.Since(start)
+	b.activation = stats
+
+	if stats.confirmed == stats.sent && stats.failed == 0 {
+		fmt.Printf("✅ All %d activation transactions confirmed in %v\n", stats.sent, stats.duration.Round(time.Millisecond))
+	} else {
+		fmt.Printf("⚠️  %d of %d accounts activated before measurement (%d still pending, %d failed)\n",
+			stats.confirmed, len(b.accounts), stats.sent-stats.confirmed, stats.failed)
+	}
+}
+
+// printActivationReport prints whether every account was activated before measurement
+func (b *Benchmark) printActivationReport() {
+	a := b.activation
+	if a == nil {
+		return
+	}
+
+	fmt.Printf("\n🔑 Account Activation:\n")
+	fmt.Printf("  Confirmed Before Run: %d of %d accounts (%v)\n", a.confirmed, len(b.accounts), a.duration.Round(time.Millisecond))
+	if a.confirmed < len(b.accounts) {
+		fmt.Printf("  ⚠️  Not every account was activated; first sends from the rest may be slower\n")
+	}
+}
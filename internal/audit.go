@@ -0,0 +1,262 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+	"github.com/unicornultrafoundation/go-u2u/ethclient"
+	"github.com/unicornultrafoundation/go-u2u/rpc"
+)
+
+// Nonce audit statuses, from healthy to worst
+const (
+	AuditOK      = "ok"      // Nothing pending
+	AuditPending = "pending" // Pending txs that are still being confirmed
+	AuditGap     = "gap"     // Queued txs behind missing nonces; they never execute until the gap is filled
+	AuditStuck   = "stuck"   // The tx at the confirmed nonce did not confirm during the observation window
+	AuditError   = "error"   // The account could not be read
+)
+
+// auditMaxMissing caps the missing nonces listed per account
+const auditMaxMissing = 100
+
+// StuckTx is the transaction blocking an account: the one at its confirmed nonce
+type StuckTx struct {
+	Nonce      uint64  `json:"nonce"`
+	Hash       string  `json:"hash,omitempty"` // Empty when txpool_content is unavailable
+	AgeSeconds float64 `json:"age_seconds"`    // Lower bound: how long it was seen unconfirmed
+}
+
+// AccountAudit is the nonce and balance state of one account
+type AccountAudit struct {
+	Account        int      `json:"account"`
+	Address        string   `json:"address"`
+	ConfirmedNonce uint64   `json:"confirmed_nonce"` // Next nonce as of the latest block
+	PendingNonce   uint64   `json:"pending_nonce"`   // Next nonce including the node's pending txs
+	BalanceWei     string   `json:"balance_wei"`
+	BalanceU2U     string   `json:"balance_u2u"`
+	PendingTxs     int      `json:"pending_txs"`              // Executable txs in the pool
+	QueuedTxs      int      `json:"queued_txs"`               // Txs waiting behind a gap
+	MissingNonces  []uint64 `json:"missing_nonces,omitempty"` // Nonces needed to unblock the queued txs (first 100)
+	StuckTx        *StuckTx `json:"stuck_tx,omitempty"`
+	Status         string   `json:"status"`
+	Error          string   `json:"error,omitempty"` // Set when the account could not be read
+}
+
+// AuditSummary counts accounts by status
+type AuditSummary struct {
+	Accounts  int `json:"accounts"`
+	OK        int `json:"ok"`
+	Pending   int `json:"pending"`
+	Gap       int `json:"gap"`
+	Stuck     int `json:"stuck"`
+	Errors    int `json:"errors"`
+	QueuedTxs int `json:"queued_txs"`
+}
+
+// NonceAudit is the machine-readable report written by cmd/audit
+type NonceAudit struct {
+	GeneratedAt     time.Time      `json:"generated_at"`
+	RPCURL          string         `json:"rpc_url"`
+	ChainID         string         `json:"chain_id"`
+	BlockNumber     uint64         `json:"block_number"`
+	ObserveSeconds  float64        `json:"observe_seconds"`  // Window used to tell pending from stuck txs
+	TxpoolAvailable bool           `json:"txpool_available"` // Without txpool_content gaps and stuck hashes cannot be detected
+	Summary         AuditSummary   `json:"summary"`
+	Accounts        []AccountAudit `json:"accounts"`
+}
+
+// pooledTx is a transaction held in the txpool
+type pooledTx struct {
+	hash   string
+	queued bool
+}
+
+// fetchPooledTxs returns, per lower-case address, the nonces and hashes the node holds in its txpool
+func fetchPooledTxs(ctx context.Context, rpcClient *rpc.Client) (map[string]map[uint64]pooledTx, error) {
+	var content txpoolContent
+	if err := rpcClient.CallContext(ctx, &content, "txpool_content"); err != nil {
+		return nil, err
+	}
+
+	pooled := make(map[string]map[uint64]pooledTx)
+	for _, section := range []struct {
+		txs    map[string]map[string]json.RawMessage
+		queued bool
+	}{{content.Pending, false}, {content.Queued, true}} {
+		for address, txs := range section.txs {
+			key := strings.ToLower(address)
+			if pooled[key] == nil {
+				pooled[key] = make(map[uint64]pooledTx)
+			}
+			for nonceStr, raw := range txs {
+				nonce, err := strconv.ParseUint(nonceStr, 10, 64)
+				if err != nil {
+					continue
+				}
+				var tx struct {
+					Hash string `json:"hash"`
+				}
+				json.Unmarshal(raw, &tx)
+				pooled[key][nonce] = pooledTx{hash: tx.Hash, queued: section.queued}
+			}
+		}
+	}
+	return pooled, nil
+}
+
+// auditSnapshot is the chain and pool state of one account at one point in time
+type auditSnapshot struct {
+	confirmed, pending uint64
+	balance            *big.Int
+	pooled             map[uint64]pooledTx
+	err                error
+}
+
+// snapshotAccounts reads the nonces, balance and pooled txs of every address
+func snapshotAccounts(ctx context.Context, client *ethclient.Client, pooled map[string]map[uint64]pooledTx, addresses []common.Address) []auditSnapshot {
+	snapshots := make([]auditSnapshot, len(addresses))
+	for i, addr := range addresses {
+		s := &snapshots[i]
+		if s.confirmed, s.err = client.NonceAt(ctx, addr, nil); s.err != nil {
+			s.err = fmt.Errorf("failed to get confirmed nonce: %v", s.err)
+			continue
+		}
+		if s.pending, s.err = client.PendingNonceAt(ctx, addr); s.err != nil {
+			s.err = fmt.Errorf("failed to get pending nonce: %v", s.err)
+			continue
+		}
+		if s.balance, s.err = client.BalanceAt(ctx, addr, nil); s.err != nil {
+			s.err = fmt.Errorf("failed to get balance: %v", s.err)
+			continue
+		}
+		s.pooled = pooled[strings.ToLower(addr.Hex())]
+	}
+	return snapshots
+}
+
+// AuditAccounts reconciles the confirmed state of every address with the node's pending state.
+// It takes two snapshots observe apart: an account whose confirmed nonce did not move while
+// the same tx sat at that nonce is reported as stuck. observe = 0 takes one snapshot and
+// never reports stuck txs. rpcClient may be nil, which skips txpool inspection.
+func AuditAccounts(ctx context.Context, client *ethclient.Client, rpcClient *rpc.Client, addresses []common.Address, observe time.Duration) (*NonceAudit, error) {
+	audit := &NonceAudit{
+		GeneratedAt:    time.Now().UTC(),
+		ObserveSeconds: observe.Seconds(),
+		Accounts:       make([]AccountAudit, len(addresses)),
+	}
+
+	readPool := func() map[string]map[uint64]pooledTx {
+		if rpcClient == nil {
+			return nil
+		}
+		pooled, err := fetchPooledTxs(ctx, rpcClient)
+		if err != nil {
+			fmt.Printf("⚠️  txpool_content unavailable (%v); gaps and stuck tx hashes will not be reported\n", err)
+			rpcClient = nil
+			return nil
+		}
+		audit.TxpoolAvailable = true
+		return pooled
+	}
+
+	first := snapshotAccounts(ctx, client, readPool(), addresses)
+	last := first
+	if observe > 0 {
+		fmt.Printf("⏳ Observing for %v to tell pending from stuck transactions...\n", observe)
+		select {
+		case <-time.After(observe):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		last = snapshotAccounts(ctx, client, readPool(), addresses)
+	}
+
+	blockNumber, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block number: %v", err)
+	}
+	audit.BlockNumber = blockNumber
+
+	for i, addr := range addresses {
+		audit.Accounts[i] = auditAccount(i, addr, first[i], last[i], observe)
+		a := &audit.Accounts[i]
+		audit.Summary.Accounts++
+		audit.Summary.QueuedTxs += a.QueuedTxs
+		switch {
+		case a.Error != "":
+			audit.Summary.Errors++
+		case a.Status == AuditStuck:
+			audit.Summary.Stuck++
+		case a.Status == AuditGap:
+			audit.Summary.Gap++
+		case a.Status == AuditPending:
+			audit.Summary.Pending++
+		default:
+			audit.Summary.OK++
+		}
+	}
+	return audit, nil
+}
+
+// auditAccount classifies one account from its first and last snapshot
+func auditAccount(id int, addr common.Address, first, last auditSnapshot, observe time.Duration) AccountAudit {
+	a := AccountAudit{Account: id, Address: addr.Hex(), Status: AuditOK}
+	for _, err := range []error{first.err, last.err} {
+		if err != nil {
+			a.Status, a.Error = AuditError, err.Error()
+			return a
+		}
+	}
+
+	a.ConfirmedNonce, a.PendingNonce = last.confirmed, last.pending
+	a.BalanceWei, a.BalanceU2U = last.balance.String(), FormatU2U(last.balance)
+
+	var queued []uint64
+	for nonce, tx := range last.pooled {
+		if nonce < last.confirmed {
+			continue // Mined since the pool was read
+		}
+		if tx.queued {
+			queued = append(queued, nonce)
+		} else {
+			a.PendingTxs++
+		}
+	}
+	if a.PendingTxs == 0 && last.pending > last.confirmed {
+		a.PendingTxs = int(last.pending - last.confirmed) // No txpool: infer from the nonces
+	}
+	a.QueuedTxs = len(queued)
+	if a.PendingTxs > 0 {
+		a.Status = AuditPending
+	}
+
+	if len(queued) > 0 {
+		sort.Slice(queued, func(i, j int) bool { return queued[i] < queued[j] })
+		a.Status = AuditGap
+		for n := last.pending; n < queued[len(queued)-1] && len(a.MissingNonces) < auditMaxMissing; n++ {
+			if _, ok := last.pooled[n]; !ok {
+				a.MissingNonces = append(a.MissingNonces, n)
+			}
+		}
+	}
+
+	// Stuck: the confirmed nonce did not advance over the window while a tx was waiting at it
+	if observe > 0 && first.confirmed == last.confirmed && last.pending > last.confirmed {
+		head, inPool := last.pooled[last.confirmed]
+		before, wasInPool := first.pooled[last.confirmed]
+		// Without txpool data the nonces alone have to do; with it, the same tx must have waited all along
+		if last.pooled == nil || (inPool && wasInPool && head.hash == before.hash) {
+			a.Status = AuditStuck
+			a.StuckTx = &StuckTx{Nonce: last.confirmed, Hash: head.hash, AgeSeconds: observe.Seconds()}
+		}
+	}
+	return a
+}
@@ -0,0 +1,154 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+)
+
+// Concurrent balance lookups when snapshotting all accounts
+const balanceFetchWorkers = 32
+
+// fetchBalances reads the latest balance of every account (nil entries for failed lookups)
+func (b *Benchmark) fetchBalances(ctx context.Context) []*big.Int {
+	balances := make([]*big.Int, len(b.accounts))
+	sem := make(chan struct{}, balanceFetchWorkers)
+	var wg sync.WaitGroup
+	for i, account := range b.accounts {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, account *AccountSender) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if balance, err := account.client.BalanceAt(ctx, account.from, nil); err == nil {
+				balances[i] = balance
+			}
+		}(i, account)
+	}
+	wg.Wait()
+	return balances
+}
+
+// AccountBalanceDelta is the balance change of one account over the run (amounts in wei)
+type AccountBalanceDelta struct {
+	AccountID  int    `json:"account_id"`
+	Address    string `json:"address"`
+	Before     string `json:"before_wei"`
+	After      string `json:"after_wei"`
+	Delta      string `json:"delta_wei"`
+	Sent       uint64 `json:"sent"`
+	Received   uint64 `json:"received"`
+	ImpliedGas string `json:"implied_gas_wei,omitempty"` // Expected value change minus actual change
+	Unexpected bool   `json:"unexpected,omitempty"`      // Implied gas is negative or above what the sends could cost
+
+	delta, impliedGas *big.Int
+}
+
+// BalanceDeltaReport compares every account's balance before and after the run
+type BalanceDeltaReport struct {
+	Accounts       []AccountBalanceDelta `json:"accounts"`
+	TotalDelta     string                `json:"total_delta_wei"`
+	ValueOut       string                `json:"value_out_wei,omitempty"`       // Value leaving the benchmark accounts (transfers to outside addresses, call values)
+	GasBurned      string                `json:"gas_burned_wei,omitempty"`      // Total decrease not explained by value leaving the accounts
+	Unreadable     int                   `json:"unreadable_accounts,omitempty"` // Accounts whose balance could not be read
+	UnexpectedRows int                   `json:"unexpected_accounts,omitempty"`
+
+	totalDelta, gasBurned *big.Int
+}
+
+// balanceDeltas compares the balances captured before the run with the current ones.
+// Value moves are predicted from the sent and received counts where every transaction
+// carries the same value (not for replays or workload mixes); the difference is gas.
+// Transactions still pending when the snapshot is taken are not reflected.
+func (b *Benchmark) balanceDeltas(after []*big.Int) *BalanceDeltaReport {
+	report := &BalanceDeltaReport{totalDelta: new(big.Int)}
+
+	// (warmup sends move balances but are not in the counts, which reset when measuring starts)
+	var value *big.Int
+	if len(b.config.WorkloadMix) == 0 && b.config.Workload != WorkloadReplay && b.config.WarmupDuration <= 0 {
+		value, _ = b.config.TxValue()
+	}
+	// Native value only stays among the benchmark accounts for plain transfers
+	internal := b.config.Workload == "" || b.config.Workload == WorkloadTransfer
+	valueOut := new(big.Int)
+
+	for i, account := range b.accounts {
+		before := b.balancesBefore[i]
+		if before == nil || after[i] == nil {
+			report.Unreadable++
+			continue
+		}
+		d := AccountBalanceDelta{
+			AccountID: i,
+			Address:   account.from.Hex(),
+			Before:    before.String(),
+			After:     after[i].String(),
+			Sent:      atomic.LoadUint64(&account.sent),
+			Received:  atomic.LoadUint64(&account.received),
+			delta:     new(big.Int).Sub(after[i], before),
+		}
+		d.Delta = d.delta.String()
+		report.totalDelta.Add(report.totalDelta, d.delta)
+
+		if value != nil {
+			out := new(big.Int).Mul(value, new(big.Int).SetUint64(d.Sent))
+			expected := new(big.Int).Neg(out)
+			if internal {
+				expected.Add(expected, new(big.Int).Mul(value, new(big.Int).SetUint64(d.Received)))
+			} else {
+				valueOut.Add(valueOut, out)
+			}
+			d.impliedGas = expected.Sub(expected, d.delta)
+			d.ImpliedGas = d.impliedGas.String()
+
+			// Each send can burn at most the jittered gas_limit × the account's gas price (fee cap for dynamic fees)
+			maxGas := new(big.Int).Mul(new(big.Int).SetUint64(b.config.MaxGasLimit()), b.gasFor(i).GasPrice)
+			maxGas.Mul(maxGas, new(big.Int).SetUint64(d.Sent))
+			if d.impliedGas.Sign() < 0 || d.impliedGas.Cmp(maxGas) > 0 {
+				d.Unexpected = true
+				report.UnexpectedRows++
+			}
+		}
+		report.Accounts = append(report.Accounts, d)
+	}
+
+	report.TotalDelta = report.totalDelta.String()
+	if value != nil {
+		report.gasBurned = new(big.Int).Neg(report.totalDelta)
+		report.gasBurned.Sub(report.gasBurned, valueOut)
+		report.GasBurned = report.gasBurned.String()
+		if valueOut.Sign() > 0 {
+			report.ValueOut = valueOut.String()
+		}
+	}
+	return report
+}
+
+// printBalanceDeltas shows the per-account balance changes and the inferred gas burned
+func printBalanceDeltas(report *BalanceDeltaReport) {
+	fmt.Printf("\n💰 Balance Changes:\n")
+	for _, d := range report.Accounts {
+		line := fmt.Sprintf("  Account %2d: %s U2U (%d sent, %d received", d.AccountID, FormatU2U(d.delta), d.Sent, d.Received)
+		if d.impliedGas != nil {
+			line += fmt.Sprintf(", implied gas %s U2U", FormatU2U(d.impliedGas))
+		}
+		line += ")"
+		if d.Unexpected {
+			line += "  ⚠️  unexpected"
+		}
+		fmt.Println(line)
+	}
+	fmt.Printf("  %-20s%s U2U\n", "Total Change:", FormatU2U(report.totalDelta))
+	if report.gasBurned != nil {
+		fmt.Printf("  %-20s%s U2U (total decrease minus value sent outside the accounts)\n", "Gas Burned:", FormatU2U(report.gasBurned))
+	}
+	if report.UnexpectedRows > 0 {
+		fmt.Printf("  ⚠️  %d accounts changed more than their sent/received counts explain; check for outside transfers or txs still pending\n",
+			report.UnexpectedRows)
+	}
+	if report.Unreadable > 0 {
+		fmt.Printf("  ⚠️  %d accounts left out (balance could not be read)\n", report.Unreadable)
+	}
+}
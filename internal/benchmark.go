@@ -2,20 +2,30 @@ package internal
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
 	"os"
+	"os/signal"
+	"runtime"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/unicornultrafoundation/go-u2u/common"
 	"github.com/unicornultrafoundation/go-u2u/core/types"
 	"github.com/unicornultrafoundation/go-u2u/ethclient"
+	"github.com/unicornultrafoundation/go-u2u/rpc"
 )
 
+// Accounts averaging more than this multiple of the overall latency are flagged as slow
+const slowAccountLatencyX = 2.0
+
+// Share of send attempts (in percent) rejected for their nonce above which the report warns
+const highNonceErrorPercent = 10.0
+
 type Benchmark struct {
 	config   *Config
 	client   *ethclient.Client
@@ -23,133 +33,711 @@ type Benchmark struct {
 
 	// Transaction settings
 	transferValue *big.Int
-	gasPrice      *big.Int
+	gas           *GasSettings
+	feeGroups     []*GasSettings // Pricing per fee group (just gas without gas_price_multipliers)
+	accountGroup  []int          // Fee group of each account
+	spend         *spendTracker  // Estimated spend and max_spend_u2u cap
 
 	// Metrics
 	sentCount    uint64 // Submitted to RPC
 	errorCount   uint64
-	totalLatency int64 // nanoseconds
+	retryCount   uint64 // Send attempts beyond the first for a transaction
+	rateLimited  uint64 // Responses rejected by the endpoint's rate limiter (HTTP 429)
+	alreadyKnown uint64 // "already known" responses counted as submitted (count_already_known_as_sent)
+	nonceErrors  uint64 // Nonce-related rejections (not counted as errors)
+	nonceResyncs uint64 // Accounts re-read from the node after a nonce error streak
+	totalLatency int64  // nanoseconds
+	latencies    latencyHistogram
+	extremes     latencyExtremes  // Fastest and slowest individual sends
+	latencyUnit  time.Duration    // Displayed latencies are rounded to this (latency_precision)
+	errorSamples *errorSampler    // First distinct send error messages with counts
+	errorKinds   errorKindCounter // Rejected attempts by error type
+	signing      signingStats     // Remote signer round trips (remote_signer_url only)
 
 	// Per-second metrics
-	tpsHistory []uint64
+	stream         *metricsStream  // JSON-lines output for dashboards (nil unless stream_file is set)
+	influx         *influxExporter // Line-protocol points (nil unless influx_file or influx_url is set)
+	accountSeries  *accountSeries  // Per-account sent/error deltas (nil unless per_account_time_series is set)
+	tpsHistory     []uint64
+	latencyHistory []time.Duration // Average send latency per interval
+
+	// Rate limiting along tps_schedule or the utilization controller (nil without either)
+	pacer            *ratePacer
+	scheduledHistory []float64 // Scheduled TPS per interval
+
+	// Send rate held at a block gas utilization (nil without target_block_utilization)
+	utilization *utilizationController
+
+	// Wall-clock time per phase of the invocation (reported with trace_phases)
+	phases *PhaseTimer
+
+	// Balances before the run and the resulting changes (only with balance_delta_report)
+	balancesBefore []*big.Int
+	balanceDelta   *BalanceDeltaReport
+
+	// Recorded transactions of the replay workload (nil for other workloads)
+	replay *replayWorkload
+
+	// Inclusion tracking (nil unless track_confirmations is set)
+	watcher        *receiptWatcher
+	backlogHistory []uint64 // submitted - confirmed at each interval
+	maxBacklog     uint64
+
+	// Early stop once the confirmed TPS holds steady (nil without stop_on_stability)
+	stability *stabilityDetector
+
+	// In-flight request tracking of the client's HTTP pool (nil for other clients)
+	pool *poolMonitor
+
+	// Transport-level retries and circuit breaking (nil unless rpc_retry_attempts or circuit_breaker_threshold is set)
+	transport transportGroup
+
+	// Endpoint answered the startup JSON-RPC batch probe
+	batchSupported bool
+
+	// Master RNG seed (seed from config, or time-based) all worker RNGs derive from
+	seed int64
+
+	// Next recipient offset in fan-out mode
+	fanOutCursor uint64
+
+	// Creates each worker's transaction builder for the configured workload
+	newBuilder func(accountID int, rng *rand.Rand) TxBuilder
+
+	// Transaction types of a workload mix and their counters (nil without workload_mix)
+	txTypes   []string
+	typeStats []txTypeStats
+
+	// Run-wide {counter} argument of the call workload
+	callCounter uint64
+
+	// Optional log of submitted transaction hashes
+	txHashLog *TxHashLog
+
+	// Load generator runtime peaks (debug_runtime only)
+	loadGen runtimeStats
 
 	// Nonce resync queue (buffered to avoid blocking)
 	resyncQueue chan *AccountSender
 
 	// Control
-	stopChan        chan struct{} // For sender workers
+	stopChan chan struct{} // For sender workers
+
+	// Context of the workers' requests, cancelled when they don't stop within shutdown_timeout_seconds
+	sendCtx         context.Context
+	cancelSends     context.CancelFunc
 	stopMetricsChan chan struct{} // For metrics reporter
 	wg              sync.WaitGroup
 
-	// Start time
-	startTime time.Time
+	// Early stop requests (soak health failures, etc.)
+	stopRequested chan struct{}
+	stopOnce      sync.Once
+	stopReason    string
+
+	// Startup watchdog state: set once anything succeeds / the first send error
+	anySucceeded uint32
+	firstError   atomic.Pointer[error]
+
+	// Time from start until total_tx_limit was reached (nanoseconds, 0 = not reached)
+	limitReachedAfter int64
+
+	// Sender workers currently running, and how many were when the window closed
+	runningWorkers int64
+	workersAtEnd   int64
+
+	// Workers still running at the shutdown timeout, and those that ignored the cancellation too
+	workersCancelled int64
+	workersAbandoned int64
+
+	// Cross-node propagation of sampled sends (nil unless track_propagation is enabled)
+	propagation *propagationTracker
+
+	// Rotating worker pool (nil unless max_workers is below the per-account worker count)
+	rotation *accountRotation
+
+	// Worker panics recovered by senderWorker
+	panicCount uint64
+
+	// Start (Unix nanoseconds, atomic: warmup resets it while workers send) and end of the send window
+	startNanos int64
+	endTime    time.Time
+
+	// One-off activation transactions sent before the run (nil unless activate_accounts is set)
+	activation *activationStats
+
+	// Chain height at the start and end of the send window
+	blocks blockRange
+
+	// Confirmed (and included at any depth) count when the send window closed, and the optional drain phase
+	windowConfirmed uint64
+	windowIncluded  uint64
+	drain           *drainStats
+
+	// Accounts dropped before the run for insufficient balance
+	skippedAccounts int
+
+	// Final results (set once the report has been produced)
+	results *Results
 }
 
 func NewBenchmark(config *Config, client *ethclient.Client, accounts []*AccountSender) (*Benchmark, error) {
-	transferValue := new(big.Int)
-	transferValue.SetString(config.TransferAmount, 10)
+	transferValue, err := config.TxValue()
+	if err != nil {
+		return nil, err
+	}
 
-	// Get current gas price
+	if err := validatePattern(config, len(accounts)); err != nil {
+		return nil, err
+	}
+	if err := validateWorkload(config); err != nil {
+		return nil, err
+	}
+	if err := validateHeadline(config); err != nil {
+		return nil, err
+	}
+	gasLimitWarning, err := checkGasLimit(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateFeeGroups(config, len(accounts)); err != nil {
+		return nil, err
+	}
+	schedule, err := newTPSSchedule(config.TPSSchedule)
+	if err != nil {
+		return nil, err
+	}
+	utilization, err := newUtilizationController(config)
+	if err != nil {
+		return nil, err
+	}
+	latencyUnit, err := config.LatencyUnit()
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve gas pricing (fixed or suggested, legacy or EIP-1559)
 	ctx := context.Background()
-	gasPrice, err := client.SuggestGasPrice(ctx)
+	gas, err := ResolveGasSettings(ctx, client, config.FixedGasPriceWei, config.EIP1559, config.Tip())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get gas price: %v", err)
+		return nil, err
 	}
 
 	fmt.Printf("\nBenchmark Configuration:\n")
-	fmt.Printf("  Transfer Mode: Round-Robin (Account i → Account i+1)\n")
-	fmt.Printf("  Transfer Value: %s wei\n", transferValue.String())
-	fmt.Printf("  Gas Price: %s wei\n", gasPrice.String())
-	fmt.Printf("  Gas Limit: %d\n", config.GasLimit)
-	fmt.Printf("  Duration: %v\n", config.GetDuration())
+	fmt.Printf("  Workload: %s\n", describeWorkload(config, transferValue))
+	fmt.Printf("  Gas Price: %s\n", gas.String())
+	feeGroups, accountGroup := feeGroupGas(config, gas, len(accounts))
+	if len(config.GasPriceMultipliers) > 0 {
+		fmt.Printf("  Fee Groups: %d (multipliers %v)\n", len(feeGroups), config.GasPriceMultipliers)
+	}
+	spend, err := newSpendTracker(config, transferValue, feeGroups)
+	if err != nil {
+		return nil, err
+	}
+	if limit := spend.limit(); limit != nil {
+		fmt.Printf("  Spend Cap: %s U2U (estimated value + gas)\n", FormatU2U(limit))
+	}
+	if config.GasLimitJitterPercent > 0 {
+		fmt.Printf("  Gas Limit: %d ±%d%% (floor %d)\n", config.GasLimit, config.GasLimitJitterPercent, intrinsicTransferGas)
+	} else {
+		fmt.Printf("  Gas Limit: %d\n", config.GasLimit)
+	}
+	if gasLimitWarning != "" {
+		fmt.Printf("  ⚠️  %s\n", gasLimitWarning)
+	}
+	if config.RunsUntilInterrupt() {
+		fmt.Printf("  Duration: until Ctrl+C\n")
+	} else {
+		fmt.Printf("  Duration: %v\n", config.GetDuration())
+	}
 	fmt.Printf("  Accounts: %d\n", len(accounts))
 	fmt.Printf("  Concurrent Senders/Account: %d \n", config.ConcurrentSendersPerAccount)
+	if config.FairNonce {
+		for _, account := range accounts {
+			account.EnableFairNonce()
+		}
+		fmt.Printf("  Fair Nonce: enabled (each account submits in nonce order)\n")
+	}
+	if config.MinAccountInterval > 0 {
+		fmt.Printf("  Min Account Interval: %dms (at most %.1f sends/s per account)\n",
+			config.MinAccountInterval, 1000/float64(config.MinAccountInterval))
+	}
+	if config.TotalTxLimit > 0 {
+		fmt.Printf("  Tx Limit: %d (stops early once reached)\n", config.TotalTxLimit)
+	}
+	if config.WarmupDuration > 0 {
+		fmt.Printf("  Warmup: %ds (excluded from metrics)\n", config.WarmupDuration)
+	}
+	if config.SoakMode {
+		fmt.Printf("  Soak Mode: enabled (runs until stopped)\n")
+	}
+	var pacer *ratePacer
+	if schedule != nil {
+		pacer = newRatePacer(schedule)
+		last := schedule[len(schedule)-1]
+		fmt.Printf("  TPS Schedule: %d points, %g → %g TPS at %gs (workers are paced)\n",
+			len(schedule), schedule[0].TPS, last.TPS, last.At)
+	}
+	if utilization != nil {
+		pacer = newRatePacer(utilization)
+		fmt.Printf("  Block Utilization Target: %g%% (from %g TPS, adjusted every %v; workers are paced)\n",
+			config.TargetBlockUtilization, config.GetUtilizationStartTPS(), utilization.interval)
+	}
+	if config.WarmCacheMode && config.RemoteSignerURL != "" {
+		return nil, fmt.Errorf("warm_cache_mode needs local keys and cannot be used with remote_signer_url")
+	}
+	if config.WarmCacheMode {
+		fmt.Printf("  ⚠️  Warm-Cache Mode: EXPERIMENTAL upper-bound microbenchmark (signatures reused, most txs will be rejected)\n")
+	}
+
+	var txHashLog *TxHashLog
+	if config.TxHashLogFile != "" {
+		txHashLog, err = NewTxHashLog(config.TxHashLogFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tx hash log: %v", err)
+		}
+		fmt.Printf("  Tx Hash Log: %s\n", config.TxHashLogFile)
+	}
+
+	var stream *metricsStream
+	if config.StreamFile != "" {
+		stream, err = newMetricsStream(config.StreamFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create metrics stream: %v", err)
+		}
+		fmt.Printf("  Metrics Stream: %s (one JSON line per interval)\n", config.StreamFile)
+	}
+
+	var influx *influxExporter
+	if config.InfluxFile != "" || config.InfluxURL != "" {
+		tags := map[string]string{"run": config.RunLabel}
+		if len(accounts) > 0 {
+			tags["chain_id"] = accounts[0].chainID.String()
+		}
+		influx, err = newInfluxExporter(config, tags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create InfluxDB output: %v", err)
+		}
+		if config.InfluxFile != "" {
+			fmt.Printf("  InfluxDB Output: %s (line protocol, one point per interval)\n", config.InfluxFile)
+		}
+		if config.InfluxURL != "" {
+			fmt.Printf("  InfluxDB Push: %s (at the end of the run)\n", config.InfluxURL)
+		}
+	}
+
+	var series *accountSeries
+	if config.PerAccountTimeSeries {
+		series = newAccountSeries(len(accounts))
+		fmt.Printf("  Per-Account Time Series: %s\n", accountSeriesFilename(config.OutputFile))
+	}
+
+	var watcher *receiptWatcher
+	if config.TrackConfirmations {
+		watcher = newReceiptWatcher(client, time.Duration(config.ConfirmationPollMs)*time.Millisecond,
+			config.GetConfirmationDepth(), config.TrackReverts, len(feeGroups), len(config.mixTypes()))
+		fmt.Printf("  Confirmation Tracking: enabled (block scan every %v)\n", watcher.pollInterval)
+		if watcher.depth > 0 {
+			fmt.Printf("  Confirmation Depth: %d blocks (one header check per block)\n", watcher.depth)
+		}
+		if config.TrackReverts {
+			fmt.Printf("  Revert Tracking: enabled (one receipt lookup per confirmed tx)\n")
+		}
+		watcher.utilization = utilization
+		if config.TrackFinality {
+			finality, err := newFinalityTracker(ctx, config)
+			if err != nil {
+				fmt.Printf("  ⚠️  Finality Tracking: skipped (%v)\n", err)
+			} else {
+				watcher.finality = finality
+				fmt.Printf("  Finality Tracking: enabled (one finalized-block lookup per scan)\n")
+			}
+		}
+	} else if config.TrackFinality {
+		fmt.Printf("  ⚠️  track_finality needs track_confirmations, skipping finality tracking\n")
+	}
+	var stability *stabilityDetector
+	if config.StopOnStability && watcher != nil {
+		stability = newStabilityDetector(config)
+		fmt.Printf("  Stop on Stability: confirmed TPS within ±%g%% for %d intervals of %ds\n",
+			config.GetStabilityBand(), stability.window, config.ReportInterval)
+	} else if config.StopOnStability {
+		fmt.Printf("  ⚠️  stop_on_stability needs track_confirmations, running the full duration\n")
+	}
 
-	return &Benchmark{
+	b := &Benchmark{
 		config:          config,
 		client:          client,
 		accounts:        accounts,
 		transferValue:   transferValue,
-		gasPrice:        gasPrice,
+		gas:             gas,
+		feeGroups:       feeGroups,
+		accountGroup:    accountGroup,
+		spend:           spend,
+		pool:            poolMonitorFor(client),
+		transport:       retryTransportsFor(client, accounts),
+		seed:            config.Seed,
+		errorSamples:    newErrorSampler(config.GetErrorSamples()),
+		txHashLog:       txHashLog,
+		stream:          stream,
+		influx:          influx,
+		accountSeries:   series,
+		watcher:         watcher,
+		pacer:           pacer,
+		utilization:     utilization,
+		stability:       stability,
+		phases:          NewPhaseTimer(),
+		latencyUnit:     latencyUnit,
 		stopChan:        make(chan struct{}),
 		stopMetricsChan: make(chan struct{}),
+		stopRequested:   make(chan struct{}),
 		tpsHistory:      make([]uint64, 0),
 		resyncQueue:     make(chan *AccountSender, 1000), // Buffer for nonce resync requests (large to handle bursts)
-	}, nil
+	}
+	b.sendCtx, b.cancelSends = context.WithCancel(context.Background())
+	if utilization != nil {
+		utilization.b = b
+	}
+	if b.seed != 0 {
+		fmt.Printf("  Seed: %d (reproducible)\n", b.seed)
+	} else {
+		b.seed = time.Now().UnixNano()
+		fmt.Printf("  Seed: %d (random; set \"seed\" to reproduce)\n", b.seed)
+	}
+	fmt.Printf("  GOMAXPROCS: %d (%d logical CPUs)\n", runtime.GOMAXPROCS(0), runtime.NumCPU())
+	if err := b.selectTxBuilder(); err != nil {
+		return nil, err
+	}
+	fmt.Printf("  Transfer Mode: %s\n", b.describePattern())
+	fmt.Printf("  Max Connections: %d\n", config.GetMaxConnections())
+	tlsConfig, err := config.tlsSettings()
+	if err != nil {
+		return nil, err
+	}
+	if err := probeBatchSupport(ctx, config.RPCURL, tlsConfig); err != nil {
+		fmt.Printf("  JSON-RPC Batching: not supported (%v)\n", err)
+	} else {
+		b.batchSupported = true
+		fmt.Printf("  JSON-RPC Batching: supported\n")
+	}
+
+	// Every in-flight send holds a connection; a smaller pool serializes workers
+	if workers := b.totalWorkers(); config.GetMaxConnections() < workers {
+		fmt.Printf("  ⚠️  max_connections (%d) is lower than the worker count (%d); sends will queue for connections\n",
+			config.GetMaxConnections(), workers)
+	}
+
+	return b, nil
 }
 
 func (b *Benchmark) Start() {
+	// Activate fresh accounts before anything is measured
+	b.phases.Begin("activation")
+	b.activateAccounts()
+	b.phases.Begin("startup")
+
 	fmt.Println("\n" + strings.Repeat("=", 70))
 	fmt.Println("STARTING BENCHMARK")
 	fmt.Println(strings.Repeat("=", 70))
 
-	b.startTime = time.Now()
+	b.markStartBlock()
+	b.markStart()
+	if b.utilization != nil {
+		b.utilization.Reset(b.utilization.current())
+	}
+	if b.pacer != nil {
+		b.pacer.Reset()
+	}
 
 	fmt.Printf("\n🚀 Starting main benchmark...")
 
 	// Multiple concurrent senders per account for pipelining
-	concurrentSenders := b.config.ConcurrentSendersPerAccount
-	if concurrentSenders <= 0 {
-		concurrentSenders = 1 // Fallback to at least 1
+	b.rotation = b.newAccountRotation()
+	if b.rotation != nil {
+		fmt.Printf("\nWorkers: %d rotating over %d accounts (max_workers, reassigned every %v)\n",
+			b.totalWorkers(), b.activeAccounts(), b.rotation.interval)
+	} else if b.replay != nil {
+		senders := len(b.replay.rows)
+		fmt.Printf("\nWorkers: %d replaying accounts × %d senders = %d concurrent workers\n",
+			senders, b.totalWorkers()/senders, b.totalWorkers())
+	} else if distributors := b.distributorCount(); distributors > 0 {
+		fmt.Printf("\nWorkers: %d distributors × %d senders = %d concurrent workers\n",
+			distributors, b.sendersForAccount(0), distributors*b.sendersForAccount(0))
+	} else {
+		fmt.Printf("\nWorkers: %d accounts × %d senders = %d concurrent workers\n",
+			len(b.accounts), b.sendersForAccount(0), len(b.accounts)*b.sendersForAccount(0))
 	}
 
-	totalWorkers := len(b.accounts) * concurrentSenders
-	fmt.Printf("\nWorkers: %d accounts × %d senders = %d concurrent workers\n",
-		len(b.accounts), concurrentSenders, totalWorkers)
+	// Balances before the first send
+	if b.config.BalanceDeltaReport {
+		b.balancesBefore = b.fetchBalances(context.Background())
+	}
 
-	// Start multiple sender goroutines per account
-	for i, account := range b.accounts {
-		for w := 0; w < concurrentSenders; w++ {
+	if b.propagation != nil {
+		b.propagation.Start()
+	}
+
+	// Start inclusion tracking before the first send
+	if b.watcher != nil {
+		if err := b.watcher.Start(context.Background()); err != nil {
+			fmt.Printf("⚠️  Confirmation tracking disabled: %v\n", err)
+			b.watcher = nil
+		}
+	}
+
+	// Start multiple sender goroutines per account, each with an RNG derived from the master seed
+	master := rand.New(rand.NewSource(b.seed))
+	if b.rotation != nil {
+		for w := 0; w < b.totalWorkers(); w++ {
 			b.wg.Add(1)
-			go b.senderWorker(i, account)
+			go b.rotatingWorker(w, master.Int63())
+		}
+	} else {
+		for i, account := range b.accounts {
+			for w := 0; w < b.sendersForAccount(i); w++ {
+				b.wg.Add(1)
+				go b.senderWorker(i, account, master.Int63())
+			}
 		}
 	}
 
-	// Start metrics reporter
-	go b.metricsReporter()
+	if b.config.DebugRuntime {
+		go b.runtimeMonitor()
+	}
+	go b.startupWatchdog()
+	if !b.config.IsReadWorkload() {
+		go b.nonceResyncWorker()
+	}
+
+	// Warm up connections, then start measuring
+	if b.runWarmup() {
+		b.phases.Begin("measure")
 
-	// Run for specified duration
-	time.Sleep(b.config.GetDuration())
+		// Start metrics reporter
+		go b.metricsReporter()
+
+		// Run for specified duration (or until a stop is requested)
+		b.waitForEnd()
+	} else {
+		go b.metricsReporter()
+	}
 
 	// Capture metrics EXACTLY at duration end (before stopping senders)
 	finalSent := atomic.LoadUint64(&b.sentCount)
 	finalErrors := atomic.LoadUint64(&b.errorCount)
 	finalLatency := atomic.LoadInt64(&b.totalLatency)
+	finalRetries := atomic.LoadUint64(&b.retryCount)
+	b.endTime = time.Now()
+	atomic.StoreInt64(&b.workersAtEnd, atomic.LoadInt64(&b.runningWorkers))
+	if b.watcher != nil {
+		b.windowConfirmed = b.watcher.Confirmed()
+		b.windowIncluded = b.watcher.Included()
+	}
+	if b.utilization != nil {
+		b.utilization.Freeze()
+	}
+	b.markEndBlock()
 
-	// Stop sender workers immediately (no more transactions)
-	close(b.stopChan)
-	b.wg.Wait()
+	// Stop sender workers immediately (no more transactions), waiting at most shutdown_timeout_seconds
+	b.phases.Begin("stop")
+	b.stopWorkers()
+	b.cancelSends()
+
+	if b.txHashLog != nil {
+		if err := b.txHashLog.Close(); err != nil {
+			fmt.Printf("Failed to write tx hash log: %v\n", err)
+		}
+	}
 
 	// Give metrics reporter time to print the final line
 	time.Sleep(150 * time.Millisecond)
 
 	// Stop metrics reporter
 	close(b.stopMetricsChan)
+	if b.stream != nil {
+		if err := b.stream.Close(); err != nil {
+			fmt.Printf("Failed to close metrics stream: %v\n", err)
+		}
+	}
+
+	// Optionally let the mempool drain before the watcher stops
+	b.phases.Begin("drain")
+	b.runDrain()
+
+	// Let the last sampled transactions reach the other nodes
+	if b.propagation != nil {
+		b.propagation.Stop()
+	}
+
+	if b.watcher != nil {
+		b.watcher.Stop()
+	}
 
 	fmt.Println("\n⏸️  Benchmark stopped")
+	fmt.Printf("   Reason: %s\n", b.stopReason)
+
+	if b.balancesBefore != nil {
+		b.phases.Begin("balances")
+		b.balanceDelta = b.balanceDeltas(b.fetchBalances(context.Background()))
+	}
+
+	b.phases.Begin("report")
+	b.printFinalReport(finalSent, finalErrors, finalRetries, finalLatency)
+}
+
+// SetSkippedAccounts records how many accounts were left out for insufficient balance
+func (b *Benchmark) SetSkippedAccounts(n int) {
+	b.skippedAccounts = n
+}
+
+// Results returns the final results, or nil if the benchmark has not finished
+func (b *Benchmark) Results() *Results {
+	return b.results
+}
+
+// waitForEnd blocks until the configured duration elapses or a stop is requested.
+// In soak mode and with until_interrupt the duration is ignored and the run ends on
+// Ctrl+C (or, in soak mode, a health failure). Only the first Ctrl+C is caught; a
+// second one during the drain or report kills the process as usual.
+func (b *Benchmark) waitForEnd() {
+	var deadline <-chan time.Time
+	if b.config.RunsUntilInterrupt() {
+		if b.config.SoakMode {
+			go b.soakMonitor()
+		}
+
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		defer signal.Stop(interrupt)
+		go func() {
+			select {
+			case <-interrupt:
+				b.requestStop("interrupted")
+			case <-b.stopRequested:
+			}
+		}()
+	} else {
+		deadline = time.After(b.config.GetDuration())
+	}
+
+	select {
+	case <-deadline:
+		b.requestStop("duration elapsed")
+	case <-b.stopRequested:
+	}
+}
+
+// requestStop ends the run early. Only the first reason is kept.
+func (b *Benchmark) requestStop(reason string) {
+	b.stopOnce.Do(func() {
+		b.stopReason = reason
+		close(b.stopRequested)
+	})
+}
+
+// limitReached reports whether total_tx_limit transactions have been submitted
+func (b *Benchmark) limitReached() bool {
+	limit := b.config.TotalTxLimit
+	return limit > 0 && atomic.LoadUint64(&b.sentCount) >= uint64(limit)
+}
 
-	b.printFinalReport(finalSent, finalErrors, finalLatency)
+// markStart starts the measured window now
+func (b *Benchmark) markStart() {
+	atomic.StoreInt64(&b.startNanos, time.Now().UnixNano())
 }
 
-func (b *Benchmark) senderWorker(id int, account *AccountSender) {
+// startTime returns when the measured window started
+func (b *Benchmark) startTime() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&b.startNanos))
+}
+
+// recordSent marks the run as alive and stops it once the submitted count hits total_tx_limit
+func (b *Benchmark) recordSent(sent uint64) {
+	if atomic.LoadUint32(&b.anySucceeded) == 0 {
+		atomic.StoreUint32(&b.anySucceeded, 1)
+	}
+
+	limit := b.config.TotalTxLimit
+	if limit > 0 && sent == uint64(limit) {
+		atomic.StoreInt64(&b.limitReachedAfter, int64(time.Since(b.startTime())))
+		b.requestStop(fmt.Sprintf("total tx limit of %d reached", limit))
+	}
+}
+
+func (b *Benchmark) senderWorker(id int, account *AccountSender, seed int64) {
 	defer b.wg.Done()
+	atomic.AddInt64(&b.runningWorkers, 1)
+	defer atomic.AddInt64(&b.runningWorkers, -1)
+
+	// Per-worker RNG so workers don't contend on the global source (and runs can be reproduced)
+	rng := rand.New(rand.NewSource(seed))
 
 	// Ultra-minimal jitter for maximum throughput
 	if id > 0 {
-		jitter := time.Duration(rand.Intn(2)) * time.Millisecond // 0-2ms only
+		jitter := time.Duration(rng.Intn(2)) * time.Millisecond // 0-2ms only
 		time.Sleep(jitter)
 	}
 
-	ctx := context.Background()
-	consecutiveErrors := 0
+	// A panic in the send loop is recovered and the loop restarted, up to max_worker_restarts times
+	maxRestarts := b.config.GetMaxWorkerRestarts()
+	for restarts := 0; b.runSender(id, account, rng, nil); restarts++ {
+		if maxRestarts >= 0 && restarts >= maxRestarts {
+			fmt.Printf("❌ Worker for account %d stopped after %d restarts\n", id, restarts)
+			return
+		}
+		select {
+		case <-b.stopChan:
+			return
+		case <-time.After(workerRestartDelay):
+		}
+	}
+}
+
+// runSender is the send loop of one worker, until the run stops or rotate fires (nil = never).
+// Returns true if it ended in a recovered panic.
+func (b *Benchmark) runSender(id int, account *AccountSender, rng *rand.Rand, rotate <-chan time.Time) (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.recordPanic(id, r)
+			panicked = true
+		}
+	}()
+
+	ctx := b.sendCtx
+	consecutiveErrors := 0       // Failed attempts in a row (excluding nonce errors), drives the backoff
 	const maxRetriesPerNonce = 2 // Minimal retries for maximum throughput
 	firstTransaction := true
 
+	// Per-worker transaction builder (reads send no transactions)
+	var builder TxBuilder
+	if !b.config.IsReadWorkload() {
+		builder = b.newBuilder(id, rng)
+	}
+
+	// Warm-cache mode: sign once, reuse the signature for every send
+	var template *txTemplate
+	if b.config.WarmCacheMode && builder != nil {
+		var err error
+		template, err = b.newTxTemplate(builder, account)
+		if err != nil {
+			fmt.Printf("❌ Worker for account %d: %v\n", id, err)
+			return
+		}
+	}
+
 	for {
 		select {
 		case <-b.stopChan:
 			return
+		case <-rotate:
+			return
 		default:
+			if b.limitReached() {
+				return
+			}
+			if b.pacer != nil && !b.pacer.Wait(b.stopChan) {
+				return
+			}
+
 			var err error
 			var latency time.Duration
 
@@ -161,15 +749,41 @@ func (b *Benchmark) senderWorker(id int, account *AccountSender) {
 			}
 
 			for retry := 0; retry < maxRetries; retry++ {
+				if retry > 0 {
+					atomic.AddUint64(&b.retryCount, 1)
+				}
+
+				// Every attempt uses a new nonce, so retries are spaced like any other send
+				if interval := b.config.MinAccountInterval; interval > 0 &&
+					!account.awaitInterval(time.Duration(interval)*time.Millisecond, b.stopChan) {
+					return
+				}
+
 				start := time.Now()
-				err = b.sendTransaction(ctx, id, account)
+				var hash common.Hash
+				hash, err = b.send(ctx, id, account, builder, template)
 				latency = time.Since(start)
+				if ctx.Err() != nil {
+					return // Cancelled at shutdown: the results are being reported without this worker
+				}
+
+				if err != nil {
+					b.errorKinds.Record(err)
+				}
 
 				if err == nil {
 					// Success! Nonce already incremented by GetNextNonce()
-					atomic.AddUint64(&b.sentCount, 1)
+					b.recordSent(atomic.AddUint64(&b.sentCount, 1))
 					atomic.AddInt64(&b.totalLatency, latency.Nanoseconds())
+					b.latencies.Record(latency)
+					b.extremes.Record(latency, hash, id)
+					if b.propagation != nil && hash != (common.Hash{}) { // Reads have no hash
+						b.propagation.Offer(hash, account.endpoint)
+					}
 					atomic.AddUint64(&account.sent, 1)
+					atomic.AddInt64(&account.latency, latency.Nanoseconds())
+					b.recordTypeSent(builder, latency)
+					atomic.StoreUint64(&account.nonceErrorStreak, 0)
 					consecutiveErrors = 0
 					firstTransaction = false
 					break
@@ -178,16 +792,27 @@ func (b *Benchmark) senderWorker(id int, account *AccountSender) {
 				// Check if it's a nonce-related error
 				if isNonceError(err) {
 					// Nonce already incremented by GetNextNonce() - transaction likely submitted
-					// No resync needed - atomic nonces handle this automatically
+					// Atomic nonces normally handle this; a long streak triggers a resync
+					atomic.AddUint64(&b.nonceErrors, 1)
+					b.noteNonceError(account)
 					consecutiveErrors = 0
 					firstTransaction = false
 					break
 				}
 
-				// For non-nonce errors (network, timeout), retry with same nonce
-				// Ultra-minimal backoff for maximum throughput
+				// Rate limited: the endpoint is throttling us, hammering it won't help
+				if isRateLimitError(err) {
+					atomic.AddUint64(&b.rateLimited, 1)
+					if retry < maxRetries-1 {
+						time.Sleep(rateLimitBackoff(rng, retry))
+					}
+					continue
+				}
+
+				// For non-nonce errors (network, timeout), retry with same nonce after a jittered backoff
+				consecutiveErrors++
 				if retry < maxRetries-1 {
-					time.Sleep(1 * time.Millisecond) // 1ms backoff only
+					time.Sleep(b.sendBackoff(rng, consecutiveErrors))
 				}
 			}
 
@@ -198,13 +823,11 @@ func (b *Benchmark) senderWorker(id int, account *AccountSender) {
 					// Only count non-nonce errors (real failures)
 					atomic.AddUint64(&b.errorCount, 1)
 					atomic.AddUint64(&account.errors, 1)
-					consecutiveErrors++
+					b.recordTypeError(builder)
+					b.recordError(err)
 
-					// Ultra-minimal backoff, maximize throughput
-					if consecutiveErrors < 5 {
-						time.Sleep(5 * time.Millisecond) // 5ms backoff
-					}
-					// Note: Nonce resync workers disabled - atomic nonces handle everything
+					// Back off before the next transaction; the wait keeps growing while the errors last
+					time.Sleep(b.sendBackoff(rng, consecutiveErrors))
 				} else {
 					// Nonce error - don't count as failure, reset consecutive error counter
 					consecutiveErrors = 0
@@ -226,33 +849,128 @@ func isNonceError(err error) bool {
 		strings.Contains(errStr, "replacement transaction underpriced")
 }
 
-func (b *Benchmark) sendTransaction(ctx context.Context, accountID int, account *AccountSender) error {
+// Helper function to detect resubmissions of a tx the node already has in its pool
+func isAlreadyKnownError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "already known")
+}
+
+// Helper function to detect rate-limit responses (HTTP 429 from public endpoints).
+// A bare "429" is not matched: it also turns up in hashes, amounts and nonces.
+func isRateLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == 429 {
+		return true
+	}
+	errStr := strings.ToLower(err.Error())
+	return strings.Contains(errStr, "rate limit") ||
+		strings.Contains(errStr, "too many requests")
+}
+
+// sendBackoff returns the wait after the n-th failed attempt in a row (n >= 1): send_backoff_base_ms
+// doubled per failure up to send_backoff_max_ms, jittered
+func (b *Benchmark) sendBackoff(rng *rand.Rand, n int) time.Duration {
+	return jitteredBackoff(b.config.GetSendBackoffBase(), b.config.GetSendBackoffMax(), n, rng)
+}
+
+// rateLimitBackoff doubles from 50ms per retry, capped at 1s, jittered
+func rateLimitBackoff(rng *rand.Rand, retry int) time.Duration {
+	return jitteredBackoff(50*time.Millisecond, time.Second, retry+1, rng)
+}
+
+// jitteredBackoff returns the wait after the n-th failure in a row (n >= 1): base doubled per
+// failure up to limit (0 = no cap), then jittered to [d/2, d) so clients that failed together
+// do not retry in lockstep. A nil rng uses the shared source.
+func jitteredBackoff(base, limit time.Duration, n int, rng *rand.Rand) time.Duration {
+	backoff := base
+	for i := 1; i < n && (limit <= 0 || backoff < limit); i++ {
+		backoff *= 2
+	}
+	if limit > 0 {
+		backoff = min(backoff, limit)
+	}
+	if backoff <= 1 {
+		return backoff
+	}
+	if rng == nil {
+		return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)))
+	}
+	return backoff/2 + time.Duration(rng.Int63n(int64(backoff/2)))
+}
+
+func (b *Benchmark) sendTransaction(ctx context.Context, accountID int, account *AccountSender, builder TxBuilder, template *txTemplate) (common.Hash, error) {
 	nonce := account.GetNextNonce()
 
-	// Round-robin: Account i sends to Account (i+1) % total_accounts
-	targetIndex := (accountID + 1) % len(b.accounts)
-	targetAddress := b.accounts[targetIndex].from
+	// The nonce passes the fair_nonce turnstile even if building or signing fails or panics
+	// (runSender recovers), or the account's other workers would wait for it forever
+	turnPassed := false
+	defer func() {
+		if !turnPassed {
+			account.awaitTurn(nonce)
+		}
+	}()
 
-	tx := types.NewTransaction(
-		nonce,
-		targetAddress,
-		b.transferValue,
-		b.config.GasLimit,
-		b.gasPrice,
-		nil,
-	)
+	tx, err := builder.Build(account, nonce)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to build transaction: %v", err)
+	}
 
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(account.chainID), account.privateKey)
+	var signedTx *types.Transaction
+	if template != nil {
+		signedTx, err = template.apply(tx)
+	} else {
+		signStart := time.Now()
+		signedTx, err = account.Sign(ctx, tx)
+		if account.signer != nil {
+			b.signing.Record(time.Since(signStart))
+		}
+	}
+	account.awaitTurn(nonce)
+	turnPassed = true
 	if err != nil {
-		return fmt.Errorf("failed to sign transaction: %v", err)
+		return common.Hash{}, fmt.Errorf("failed to sign transaction: %v", err)
+	}
+
+	if b.watcher != nil {
+		b.watcher.Track(signedTx.Hash(), time.Now(), b.accountGroup[accountID], txTypeOf(builder))
 	}
 
 	err = account.client.SendTransaction(ctx, signedTx)
+
+	// Optionally treat "already known" as submitted: the tx is in the mempool
+	if err != nil && b.config.CountAlreadyKnownAsSent && isAlreadyKnownError(err) {
+		atomic.AddUint64(&b.alreadyKnown, 1)
+		err = nil
+	}
+	// Sends are cancelled at shutdown; by then the worker may be abandoned and the hash log
+	// closed, so a late success is not recorded
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
 	if err != nil {
-		return err
+		if b.watcher != nil {
+			b.watcher.Forget(signedTx.Hash())
+		}
+		return common.Hash{}, err
+	}
+
+	if r, ok := builder.(recipientReporter); ok && r.LastRecipient() != nil {
+		atomic.AddUint64(&r.LastRecipient().received, 1)
+	}
+	if b.spend.add(b.accountGroup[accountID]) {
+		b.requestStop(fmt.Sprintf("spend cap of %s U2U reached", FormatU2U(b.spend.limit())))
 	}
 
-	return nil
+	if b.txHashLog != nil {
+		b.txHashLog.Record(signedTx.Hash())
+	}
+
+	return signedTx.Hash(), nil
 }
 
 func (b *Benchmark) metricsReporter() {
@@ -260,12 +978,25 @@ func (b *Benchmark) metricsReporter() {
 	defer ticker.Stop()
 
 	lastSent := uint64(0)
+	lastLatency := int64(0)
+	lastConfirmed := uint64(0)
 	reportCount := 0
 
-	fmt.Println("\n" + strings.Repeat("-", 85))
-	fmt.Printf("%-10s | %-13s | %-15s | %-10s | %-12s\n",
-		"Time", "Submitted TPS", "Total Submitted", "Errors", "Avg Latency")
-	fmt.Println(strings.Repeat("-", 85))
+	tableWidth := 85
+	header := fmt.Sprintf("%-10s | %-13s | %-15s | %-10s | %-12s",
+		"Time", b.rateLabel(), "Total Submitted", "Errors", "Avg Latency")
+	if b.pacer != nil {
+		tableWidth += 12
+		header += fmt.Sprintf(" | %-9s", "Target")
+	}
+	if b.watcher != nil {
+		tableWidth += 28
+		header += fmt.Sprintf(" | %-11s | %-10s", "Confirmed", "Backlog")
+	}
+
+	fmt.Println("\n" + strings.Repeat("-", tableWidth))
+	fmt.Println(header)
+	fmt.Println(strings.Repeat("-", tableWidth))
 
 	for {
 		select {
@@ -280,23 +1011,84 @@ func (b *Benchmark) metricsReporter() {
 			submittedTPS := sent - lastSent
 			b.tpsHistory = append(b.tpsHistory, submittedTPS)
 
+			intervalLatency := time.Duration(0)
+			if submittedTPS > 0 {
+				intervalLatency = time.Duration((totalLat - lastLatency) / int64(submittedTPS))
+			}
+			b.latencyHistory = append(b.latencyHistory, intervalLatency)
+			if b.accountSeries != nil {
+				b.accountSeries.Record(b.accounts)
+			}
+
 			avgLatency := time.Duration(0)
 			if sent > 0 {
 				avgLatency = time.Duration(totalLat / int64(sent))
 			}
 
-			elapsed := time.Since(b.startTime)
-			fmt.Printf("%-10s | %-13d | %-15d | %-10d | %-12s\n",
+			elapsed := time.Since(b.startTime())
+			line := fmt.Sprintf("%-10s | %-13d | %-15d | %-10d | %-12s",
 				formatDuration(elapsed), submittedTPS, sent, errors,
-				avgLatency.Round(time.Millisecond))
+				b.roundLatency(avgLatency))
+			record := StreamRecord{
+				ElapsedSeconds: elapsed.Seconds(),
+				Submitted:      submittedTPS,
+				TotalSubmitted: sent,
+				TotalErrors:    errors,
+				AvgLatencyMs:   intervalLatency.Milliseconds(),
+			}
+
+			// Scheduled rate for the interval that just ended
+			if b.pacer != nil {
+				scheduled := b.scheduledAt(elapsed)
+				b.scheduledHistory = append(b.scheduledHistory, scheduled)
+				line += fmt.Sprintf(" | %-9.1f", scheduled)
+				record.ScheduledTPS = &scheduled
+			}
+
+			// In-flight backlog: submitted but not yet seen in a block
+			if b.watcher != nil {
+				confirmed := b.watcher.Confirmed()
+				backlog := uint64(0)
+				if sent > confirmed {
+					backlog = sent - confirmed
+				}
+				b.backlogHistory = append(b.backlogHistory, backlog)
+				if backlog > b.maxBacklog {
+					b.maxBacklog = backlog
+				}
+				line += fmt.Sprintf(" | %-11d | %-10d", confirmed, backlog)
+				record.Confirmed, record.Backlog = &confirmed, &backlog
+
+				// Stop once the confirmed rate has settled
+				if b.stability != nil && confirmed >= lastConfirmed {
+					confirmedTPS := float64(confirmed-lastConfirmed) / float64(b.config.ReportInterval)
+					if b.stability.Record(confirmedTPS, elapsed) {
+						b.requestStop(b.stability.reason())
+					}
+				}
+				lastConfirmed = confirmed
+			}
+			fmt.Println(line)
+
+			if b.stream != nil {
+				if err := b.stream.Write(record, b.errorKinds.Snapshot()); err != nil {
+					fmt.Printf("⚠️  Metrics stream write failed, disabling it: %v\n", err)
+				}
+			}
+			if b.influx != nil {
+				if err := b.influx.WriteInterval(record, time.Now()); err != nil {
+					fmt.Printf("⚠️  InfluxDB file write failed, disabling it: %v\n", err)
+				}
+			}
 
 			lastSent = sent
+			lastLatency = totalLat
 		}
 	}
 }
 
-func (b *Benchmark) printFinalReport(sent, errors uint64, totalLat int64) {
-	elapsed := time.Since(b.startTime)
+func (b *Benchmark) printFinalReport(sent, errors, retries uint64, totalLat int64) {
+	elapsed := b.endTime.Sub(b.startTime())
 
 	avgSubmittedTPS := float64(sent) / elapsed.Seconds()
 	avgLatency := time.Duration(0)
@@ -304,27 +1096,176 @@ func (b *Benchmark) printFinalReport(sent, errors uint64, totalLat int64) {
 		avgLatency = time.Duration(totalLat / int64(sent))
 	}
 
+	// Headline numbers come from the full intervals only when the tail is excluded
+	headlineTPS, headlineLatency, tailExcluded := b.headlineIntervals()
+	if tailExcluded {
+		avgSubmittedTPS, avgLatency = intervalAverages(headlineTPS, headlineLatency, b.config.ReportInterval)
+	}
+
 	// Calculate min/max/median TPS for submitted
-	minSubmittedTPS, maxSubmittedTPS, medianSubmittedTPS := calculateTPSStats(b.tpsHistory)
+	minSubmittedTPS, maxSubmittedTPS, medianSubmittedTPS := calculateTPSStats(headlineTPS)
 
 	fmt.Println("\n" + strings.Repeat("=", 70))
 	fmt.Println("BENCHMARK RESULTS")
 	fmt.Println(strings.Repeat("=", 70))
 
+	confirmedTPS := 0.0
+	if b.watcher != nil {
+		confirmedTPS = float64(b.windowConfirmed) / elapsed.Seconds()
+	}
+	b.printHeadline(b.config.headlineTPS(avgSubmittedTPS, confirmedTPS))
+
 	fmt.Printf("\n📊 Overall Statistics:\n")
 	fmt.Printf("  Duration:           %v\n", elapsed.Round(time.Second))
 	fmt.Printf("  Total Submitted:    %d transactions\n", sent)
 	fmt.Printf("  Total Errors:       %d transactions\n", errors)
 	fmt.Printf("  RPC Accept Rate:    %.2f%%\n", float64(sent)/float64(sent+errors)*100)
+	fmt.Printf("  Total Retries:      %d (%.2f per successful tx)\n", retries, retriesPerSuccess(retries, sent))
+	if alreadyKnown := atomic.LoadUint64(&b.alreadyKnown); alreadyKnown > 0 {
+		fmt.Printf("  Already Known:      %d (counted as submitted)\n", alreadyKnown)
+	}
+	if rateLimited := atomic.LoadUint64(&b.rateLimited); rateLimited > 0 {
+		fmt.Printf("  Rate Limited:       %d responses (HTTP 429)\n", rateLimited)
+	}
+	if nonceErrors := atomic.LoadUint64(&b.nonceErrors); nonceErrors > 0 || b.config.FairNonce {
+		percent := b.nonceErrorPercent(sent)
+		fmt.Printf("  Nonce Errors:       %d (%.2f%% of send attempts, not counted as errors)\n", nonceErrors, percent)
+		if percent >= highNonceErrorPercent {
+			fmt.Printf("  ⚠️  The senders are outrunning the node; lower the target TPS or concurrent_senders_per_account\n")
+		}
+	}
+	if resyncs := atomic.LoadUint64(&b.nonceResyncs); resyncs > 0 {
+		fmt.Printf("  Nonce Resyncs:      %d (after %d+ consecutive nonce errors)\n", resyncs, b.config.GetNonceResyncThreshold())
+	}
+	if !b.config.IsReadWorkload() {
+		fmt.Printf("  Estimated Spend:    %s U2U", FormatU2U(b.spend.spent()))
+		if limit := b.spend.limit(); limit != nil {
+			fmt.Printf(" (cap %s U2U)", FormatU2U(limit))
+		}
+		fmt.Println()
+	}
+	if limitAfter := time.Duration(atomic.LoadInt64(&b.limitReachedAfter)); limitAfter > 0 {
+		fmt.Printf("  Time to %d txs:     %v\n", b.config.TotalTxLimit, limitAfter.Round(time.Millisecond))
+	}
 
-	fmt.Printf("\n⚡ Submitted TPS Metrics:\n")
-	fmt.Printf("  Average TPS:        %.2f\n", avgSubmittedTPS)
-	fmt.Printf("  Peak TPS:           %d\n", maxSubmittedTPS)
-	fmt.Printf("  Minimum TPS:        %d\n", minSubmittedTPS)
-	fmt.Printf("  Median TPS:         %d\n", medianSubmittedTPS)
+	if b.watcher != nil {
+		confirmed := b.windowConfirmed
+		fmt.Printf("\n✅ Confirmation Metrics:\n")
+		fmt.Printf("  Total Confirmed:    %d transactions\n", confirmed)
+		fmt.Printf("  Confirmed TPS:      %.2f\n", confirmedTPS)
+		if b.watcher.depth > 0 {
+			fmt.Printf("  Confirmation Depth: %d blocks (%d included at depth 0, %d not yet deep enough)\n",
+				b.watcher.depth, b.windowIncluded, b.windowIncluded-confirmed)
+			if reorged := b.watcher.Reorged(); reorged > 0 {
+				fmt.Printf("  Reorged:            %d included transactions dropped by reorgs\n", reorged)
+			}
+		}
+		fmt.Printf("  Peak Backlog:       %d in-flight transactions\n", b.maxBacklog)
+		b.printStabilityLine()
+		if b.config.TrackReverts {
+			reverted, checked := b.watcher.Reverted()
+			fmt.Printf("  Reverted:           %d of %d checked (%.2f%%)\n", reverted, checked, revertRate(reverted, checked))
+		}
+		if fastest, slowest := b.watcher.confirmExtremes.Extremes(); fastest != nil {
+			fmt.Printf("  Fastest Confirm:    %s\n", fastest)
+			fmt.Printf("  Slowest Confirm:    %s\n", slowest)
+		}
+	}
+	b.printFinalityReport()
+	b.printActivationReport()
+	b.printDrainReport()
+	b.printBlockRangeReport()
+	b.printFeeGroupReport()
+	b.printTxTypeReport(elapsed)
+
+	fmt.Printf("\n⚡ %s Metrics:\n", b.rateLabel())
+	if tailExcluded {
+		fmt.Printf("  (last interval excluded: %d of %d intervals used)\n", len(headlineTPS), len(b.tpsHistory))
+	}
+	fmt.Printf("  Average:            %.2f\n", avgSubmittedTPS)
+	fmt.Printf("  Peak:               %d\n", maxSubmittedTPS)
+	fmt.Printf("  Minimum:            %d\n", minSubmittedTPS)
+	fmt.Printf("  Median:             %d\n", medianSubmittedTPS)
+
+	b.printScheduleReport()
+	b.printUtilizationReport()
+
+	if b.config.TPSHistogram {
+		if lines := tpsHistogramLines(b.tpsHistory); lines != nil {
+			fmt.Printf("\n📶 %s Distribution (intervals per range):\n", b.rateLabel())
+			for _, line := range lines {
+				fmt.Printf("  %s\n", line)
+			}
+		}
+	}
 
 	fmt.Printf("\n⏱️  Latency:\n")
-	fmt.Printf("  Average Latency:    %v\n", avgLatency.Round(time.Millisecond))
+	fmt.Printf("  Average Latency:    %v\n", b.roundLatency(avgLatency))
+	fmt.Printf("  P50 Latency:        %v\n", b.roundLatency(b.latencies.Percentile(50)))
+	fmt.Printf("  P95 Latency:        %v\n", b.roundLatency(b.latencies.Percentile(95)))
+	fmt.Printf("  P99 Latency:        %v\n", b.roundLatency(b.latencies.Percentile(99)))
+	if fastest, slowest := b.extremes.Extremes(); fastest != nil {
+		fmt.Printf("  Fastest Send:       %s\n", fastest)
+		fmt.Printf("  Slowest Send:       %s\n", slowest)
+	}
+	coldLatency, coldTPS, haveColdStart := coldStartCost(b.tpsHistory, b.latencyHistory)
+	if haveColdStart {
+		fmt.Printf("  Cold Start Cost:    %+dms latency / first interval at %.0f%% of steady-state TPS\n",
+			coldLatency.Milliseconds(), coldTPS)
+	}
+
+	b.printSigningReport(avgLatency)
+	b.printPoolReport()
+	b.printTransportReport()
+	b.printEndpointReport()
+	b.printPropagationReport()
+	b.printRuntimeReport()
+	b.printErrorSamples()
+
+	fmt.Printf("\n🩺 Diagnostics:\n")
+	errorRate := 0.0
+	if sent+errors > 0 {
+		errorRate = float64(errors) / float64(sent+errors) * 100
+	}
+	diagnostics := diagnose(minSubmittedTPS, maxSubmittedTPS, avgSubmittedTPS, errorRate,
+		b.latencies.Percentile(50), b.latencies.Percentile(99))
+	if b.watcher != nil && b.config.TrackReverts {
+		reverted, checked := b.watcher.Reverted()
+		if rate := revertRate(reverted, checked); rate > b.config.GetRevertWarnPercent() {
+			diagnostics = append(diagnostics, fmt.Sprintf("Reverts: %.2f%% of confirmed transactions reverted (threshold %.2f%%) — they burn gas without doing work; check gas limit and token balances",
+				rate, b.config.GetRevertWarnPercent()))
+		}
+	}
+	if rateLimited := atomic.LoadUint64(&b.rateLimited); rateLimited > 0 {
+		diagnostics = append(diagnostics, fmt.Sprintf("Rate limited: the RPC endpoint rejected %d requests with HTTP 429 — the endpoint is throttling, not the chain; use a private node or fewer workers",
+			rateLimited))
+	}
+	if b.pool != nil && b.pool.Saturated() {
+		diagnostics = append(diagnostics, fmt.Sprintf("Connection pool saturated: %d concurrent requests hit max_connections (%d) — requests queued for a connection; raise max_connections",
+			b.pool.Peak(), b.pool.limit))
+	}
+	if b.transport != nil {
+		if stats := b.transport.Stats(); stats.CircuitOpened > 0 {
+			diagnostics = append(diagnostics, fmt.Sprintf("Circuit breaker: the endpoint's circuit opened %d times and %d requests were rejected — the RPC endpoint was failing consistently",
+				stats.CircuitOpened, stats.CircuitRejected))
+		}
+	}
+	if len(diagnostics) == 0 {
+		fmt.Printf("  ✅ No anomalies detected\n")
+	}
+	for _, d := range diagnostics {
+		fmt.Printf("  ⚠️  %s\n", d)
+	}
+
+	b.printConcurrencyReport()
+
+	uniqueSenders, uniqueRecipients := b.uniqueParticipants()
+	fmt.Printf("\n🧭 Account Coverage:\n")
+	fmt.Printf("  Distinct Senders:    %d of %d accounts\n", uniqueSenders, len(b.accounts))
+	fmt.Printf("  Distinct Recipients: %d of %d accounts\n", uniqueRecipients, len(b.accounts))
+	if b.skippedAccounts > 0 {
+		fmt.Printf("  Skipped Accounts:    %d (insufficient balance)\n", b.skippedAccounts)
+	}
 
 	fmt.Printf("\n👥 Per-Account Statistics:\n")
 	for i, account := range b.accounts {
@@ -334,19 +1275,38 @@ func (b *Benchmark) printFinalReport(sent, errors uint64, totalLat int64) {
 		if sent+errors > 0 {
 			successRate = float64(sent) / float64(sent+errors) * 100
 		}
-		fmt.Printf("  Account %2d: %6d sent, %4d errors (%.1f%%)\n",
-			i, sent, errors, successRate)
+		accountLatency := account.AvgLatency()
+		slowMarker := ""
+		if avgLatency > 0 && accountLatency > slowAccountLatencyX*avgLatency {
+			slowMarker = "  ⚠️  slow"
+		}
+		fmt.Printf("  Account %2d: %6d sent, %4d errors (%.1f%%), avg latency %v%s\n",
+			i, sent, errors, successRate, b.roundLatency(accountLatency), slowMarker)
+	}
+
+	if b.balanceDelta != nil {
+		printBalanceDeltas(b.balanceDelta)
+	}
+
+	if distributors := b.distributorCount(); distributors > 0 {
+		fmt.Printf("\n📤 Distributor Nonce Rate:\n")
+		for i := 0; i < distributors; i++ {
+			sent := atomic.LoadUint64(&b.accounts[i].sent)
+			fmt.Printf("  Account %2d: %.2f nonces/s (%d sent)\n", i, float64(sent)/elapsed.Seconds(), sent)
+		}
 	}
 
+	b.printPhaseReport()
+
 	fmt.Println("\n" + strings.Repeat("=", 70))
 
 	// Save results
 	b.saveResults(elapsed, avgSubmittedTPS, sent, errors,
-		minSubmittedTPS, maxSubmittedTPS, medianSubmittedTPS, avgLatency)
+		minSubmittedTPS, maxSubmittedTPS, medianSubmittedTPS, avgLatency, retries, diagnostics)
 }
 
 func (b *Benchmark) saveResults(duration time.Duration, avgSubmittedTPS float64, sent, errors uint64,
-	minSubmittedTPS, maxSubmittedTPS, medianSubmittedTPS uint64, avgLatency time.Duration) {
+	minSubmittedTPS, maxSubmittedTPS, medianSubmittedTPS uint64, avgLatency time.Duration, retries uint64, diagnostics []string) {
 
 	// Calculate rates
 	rpcAcceptRate := 0.0
@@ -364,67 +1324,201 @@ func (b *Benchmark) saveResults(duration time.Duration, avgSubmittedTPS float64,
 			accountSuccessRate = float64(sent) / float64(sent+errors) * 100
 		}
 		accountStats = append(accountStats, map[string]interface{}{
-			"account_id":   i,
-			"address":      account.from.Hex(),
-			"sent":         sent,
-			"errors":       errors,
-			"success_rate": accountSuccessRate,
+			"account_id":     i,
+			"address":        account.from.Hex(),
+			"sent":           sent,
+			"errors":         errors,
+			"success_rate":   accountSuccessRate,
+			"nonce_rate":     float64(sent) / duration.Seconds(),
+			"received":       atomic.LoadUint64(&account.received),
+			"avg_latency_ms": account.AvgLatency().Milliseconds(),
 		})
 	}
 
-	// Use struct to ensure consistent field order
-	type BenchmarkResults struct {
-		Timestamp           string                   `json:"timestamp"`
-		Config              map[string]interface{}   `json:"config"`
-		TotalSubmitted      uint64                   `json:"total_submitted"`
-		TotalErrors         uint64                   `json:"total_errors"`
-		RPCAcceptRate       float64                  `json:"rpc_accept_rate"`
-		AvgSubmittedTPS     float64                  `json:"average_submitted_tps"`
-		PeakSubmittedTPS    uint64                   `json:"peak_submitted_tps"`
-		MinSubmittedTPS     uint64                   `json:"min_submitted_tps"`
-		MedianSubmittedTPS  uint64                   `json:"median_submitted_tps"`
-		AvgLatencyMs        int64                    `json:"average_latency_ms"`
-		SubmittedTPSHistory []uint64                 `json:"submitted_tps_history"`
-		AccountStats        []map[string]interface{} `json:"account_statistics"`
-	}
-
-	results := BenchmarkResults{
-		Timestamp: time.Now().Format(time.RFC3339),
+	results := Results{
+		Timestamp:  time.Now().Format(time.RFC3339),
+		StopReason: b.stopReason,
+		Seed:       b.seed,
+		NumCPU:     runtime.NumCPU(),
+		GoMaxProcs: runtime.GOMAXPROCS(0),
+		RunLabel:   b.config.RunLabel,
+		RPCBatch:   b.batchSupported,
 		Config: map[string]interface{}{
-			"rpc_url":             b.config.RPCURL,
-			"gas_limit":           b.config.GasLimit,
-			"transfer_amount_wei": b.config.TransferAmount,
-			"duration_seconds":    duration.Seconds(),
-			"num_accounts":        len(b.accounts),
+			"rpc_url":                  b.config.RPCURL,
+			"gas_limit":                b.config.GasLimit,
+			"gas_limit_jitter_percent": b.config.GasLimitJitterPercent,
+			"transfer_amount_wei":      b.config.TransferAmount,
+			"duration_seconds":         duration.Seconds(),
+			"num_accounts":             len(b.accounts),
+			"transfer_pattern":         b.config.TransferPattern,
+			"workload":                 b.config.Workload,
+			"workload_mix":             b.config.WorkloadMix,
+			"soak_mode":                b.config.SoakMode,
+			"until_interrupt":          b.config.UntilInterrupt,
+			"total_tx_limit":           b.config.TotalTxLimit,
+			"exclude_tail_interval":    b.config.ExcludeTailInterval,
+			"fair_nonce":               b.config.FairNonce,
+			"tps_schedule":             b.config.TPSSchedule,
+			"gas_pricing":              b.gas.String(),
 		},
 		TotalSubmitted:      sent,
 		TotalErrors:         errors,
 		RPCAcceptRate:       rpcAcceptRate,
+		TotalRetries:        retries,
+		RetriesPerSuccess:   retriesPerSuccess(retries, sent),
+		RateLimitHits:       atomic.LoadUint64(&b.rateLimited),
+		AlreadyKnownCounted: atomic.LoadUint64(&b.alreadyKnown),
+		NonceErrors:         atomic.LoadUint64(&b.nonceErrors),
+		NonceErrorPercent:   b.nonceErrorPercent(sent),
+		NonceResyncs:        atomic.LoadUint64(&b.nonceResyncs),
 		AvgSubmittedTPS:     avgSubmittedTPS,
 		PeakSubmittedTPS:    maxSubmittedTPS,
 		MinSubmittedTPS:     minSubmittedTPS,
 		MedianSubmittedTPS:  medianSubmittedTPS,
 		AvgLatencyMs:        avgLatency.Milliseconds(),
+		P50LatencyMs:        b.latencies.Percentile(50).Milliseconds(),
+		P95LatencyMs:        b.latencies.Percentile(95).Milliseconds(),
+		P99LatencyMs:        b.latencies.Percentile(99).Milliseconds(),
+		EstimatedSpendWei:   b.spend.spent().String(),
+		TimeToLimitSeconds:  time.Duration(atomic.LoadInt64(&b.limitReachedAfter)).Seconds(),
 		SubmittedTPSHistory: b.tpsHistory,
+		ScheduledTPSHistory: b.scheduledHistory,
+		ErrorsByType:        errorKindMap(b.errorKinds.Snapshot()),
 		AccountStats:        accountStats,
+		Diagnostics:         diagnostics,
+	}
+	if len(b.accounts) > 0 {
+		results.ChainID = b.accounts[0].chainID.Int64()
+	}
+	if b.pacer != nil {
+		results.IntervalsBehindSchedule = b.intervalsBehindSchedule()
+	}
+	results.UniqueSenders, results.UniqueRecipients = b.uniqueParticipants()
+	results.SkippedAccounts = b.skippedAccounts
+	results.Concurrency = b.concurrencyStats()
+	results.FeeGroups = b.feeGroupResults()
+	b.blockRangeResults(&results)
+	b.finalityResults(&results)
+	b.signingResults(&results)
+	if b.activation != nil {
+		results.ActivationSent = b.activation.sent
+		results.ActivationConfirmed = b.activation.confirmed
+	}
+	results.FastestSend, results.SlowestSend = b.extremes.Extremes()
+	results.TxTypes = b.txTypeResults(duration)
+	if coldLatency, coldTPS, ok := coldStartCost(b.tpsHistory, b.latencyHistory); ok {
+		results.ColdStartLatencyMs = coldLatency.Milliseconds()
+		results.ColdStartTPSPercent = coldTPS
+	}
+	if b.watcher != nil {
+		results.TotalConfirmed = b.windowConfirmed
+		if b.watcher.depth > 0 {
+			results.ConfirmationDepth = b.watcher.depth
+			results.TotalIncluded = b.windowIncluded
+			results.TotalReorged = b.watcher.Reorged()
+		}
+		results.AvgConfirmedTPS = float64(results.TotalConfirmed) / duration.Seconds()
+		results.MaxInflightBacklog = b.maxBacklog
+		results.InflightBacklogHistory = b.backlogHistory
+		if b.stability != nil && b.stability.stableAfter > 0 {
+			results.StableConfirmedTPS = b.stability.stableTPS
+			results.StabilizedAfterSeconds = b.stability.stableAfter.Seconds()
+		}
+		results.FastestConfirmation, results.SlowestConfirmation = b.watcher.confirmExtremes.Extremes()
+		if b.config.TrackReverts {
+			reverted, checked := b.watcher.Reverted()
+			results.TotalReverted = reverted
+			results.RevertRate = revertRate(reverted, checked)
+		}
+	}
+	results.HeadlineMetric = b.config.GetHeadlineMetric()
+	results.HeadlineTPS = b.config.headlineTPS(results.AvgSubmittedTPS, results.AvgConfirmedTPS)
+	results.MinTPS = b.config.MinTPS
+	results.ErrorSamples, results.UnsampledErrors = b.errorSamples.Samples()
+	if b.pool != nil {
+		results.PeakInflightRequests = b.pool.Peak()
+		results.MaxConnections = b.pool.limit
+	}
+	if b.transport != nil {
+		stats := b.transport.Stats()
+		results.Transport = &stats
 	}
+	results.BalanceDeltas = b.balanceDelta
+	results.Endpoints = b.endpointStats()
+	results.Propagation = b.propagationStats()
+	results.BlockUtilization = b.utilizationStats()
+	if b.config.TracePhases {
+		results.Phases = b.phases.Phases()
+	}
+	if b.config.DebugRuntime {
+		results.PeakGoroutines = atomic.LoadUint64(&b.loadGen.peakGoroutines)
+		results.MaxHeapMB = float64(atomic.LoadUint64(&b.loadGen.peakHeapBytes)) / (1024 * 1024)
+		results.MaxGCPauseMs = float64(atomic.LoadUint64(&b.loadGen.maxGCPauseNs)) / 1e6
+	}
+	if b.drain != nil {
+		results.DrainSeconds = b.drain.duration.Seconds()
+		results.DrainConfirmed = b.drain.confirmedFinal - b.drain.confirmedAtEnd
+		results.DrainConfirmedHistory = b.drain.confirmedSeries
+		results.FinalInclusionRate = b.drain.inclusionRate()
+	}
+	b.results = &results
+	defer b.exportPrometheus()
+	defer b.exportInflux()
+	defer b.writePhaseTrace()
+	defer b.saveAccountSeries()
 
-	file, err := os.Create(b.config.OutputFile)
-	if err != nil {
+	if err := results.Save(b.config.OutputFile); err != nil {
 		fmt.Printf("Failed to save results: %v\n", err)
 		return
 	}
-	defer file.Close()
-
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	encoder.Encode(results)
 
 	fmt.Printf("📝 Results saved to %s\n", b.config.OutputFile)
 }
 
+// revertRate is the percentage of status-checked transactions that reverted
+func revertRate(reverted, checked uint64) float64 {
+	if checked == 0 {
+		return 0
+	}
+	return float64(reverted) / float64(checked) * 100
+}
+
+// uniqueParticipants counts accounts that sent and received at least one transfer
+func (b *Benchmark) uniqueParticipants() (senders, recipients int) {
+	for _, account := range b.accounts {
+		if atomic.LoadUint64(&account.sent) > 0 {
+			senders++
+		}
+		if atomic.LoadUint64(&account.received) > 0 {
+			recipients++
+		}
+	}
+	return senders, recipients
+}
+
 // Helper functions
 
+// retriesPerSuccess is the average number of extra attempts per submitted transaction
+func retriesPerSuccess(retries, sent uint64) float64 {
+	if sent == 0 {
+		return 0
+	}
+	return float64(retries) / float64(sent)
+}
+
+// nonceErrorPercent is the share of send attempts (accepted plus every rejected attempt,
+// retries included) that were rejected for their nonce
+func (b *Benchmark) nonceErrorPercent(sent uint64) float64 {
+	attempts := sent
+	for _, rejected := range b.errorKinds.Snapshot() {
+		attempts += rejected
+	}
+	if attempts == 0 {
+		return 0
+	}
+	return float64(atomic.LoadUint64(&b.nonceErrors)) / float64(attempts) * 100
+}
+
 func formatDuration(d time.Duration) string {
 	d = d.Round(time.Second)
 	m := d / time.Minute
@@ -432,6 +1526,38 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%02d:%02d", m, s)
 }
 
+// headlineIntervals returns the interval history used for the headline numbers.
+// With exclude_tail_interval the last interval (workers draining) is dropped;
+// it stays in the exported history either way.
+func (b *Benchmark) headlineIntervals() (tps []uint64, latency []time.Duration, tailExcluded bool) {
+	if !b.config.ExcludeTailInterval || len(b.tpsHistory) < 2 || len(b.latencyHistory) != len(b.tpsHistory) {
+		return b.tpsHistory, b.latencyHistory, false
+	}
+	last := len(b.tpsHistory) - 1
+	return b.tpsHistory[:last], b.latencyHistory[:last], true
+}
+
+// intervalAverages computes average TPS and send-weighted average latency from interval samples
+func intervalAverages(tps []uint64, latency []time.Duration, intervalSeconds int) (avgTPS float64, avgLatency time.Duration) {
+	if intervalSeconds <= 0 {
+		intervalSeconds = 1
+	}
+
+	var total uint64
+	var weightedLatency float64
+	for i, count := range tps {
+		total += count
+		weightedLatency += float64(latency[i]) * float64(count)
+	}
+	if len(tps) > 0 {
+		avgTPS = float64(total) / float64(len(tps)*intervalSeconds)
+	}
+	if total > 0 {
+		avgLatency = time.Duration(weightedLatency / float64(total))
+	}
+	return avgTPS, avgLatency
+}
+
 func calculateTPSStats(tpsHistory []uint64) (min, max, median uint64) {
 	if len(tpsHistory) == 0 {
 		return 0, 0, 0
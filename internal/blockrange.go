@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// blockRange is the chain height at the start and end of the measured window,
+// for correlating a run with explorer data
+type blockRange struct {
+	start, end uint64
+	startKnown bool
+	endKnown   bool
+}
+
+// produced returns how many blocks were produced during the window
+func (r blockRange) produced() (uint64, bool) {
+	if !r.startKnown || !r.endKnown || r.end < r.start {
+		return 0, false
+	}
+	return r.end - r.start, true
+}
+
+// latestBlock fetches the current block number, warning instead of failing the run
+func (b *Benchmark) latestBlock(when string) (uint64, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	number, err := b.client.BlockNumber(ctx)
+	if err != nil {
+		fmt.Printf("⚠️  Failed to get %s block number: %v\n", when, err)
+		return 0, false
+	}
+	return number, true
+}
+
+// markStartBlock records the block the measured window starts at (again after warmup)
+func (b *Benchmark) markStartBlock() {
+	b.blocks.start, b.blocks.startKnown = b.latestBlock("start")
+}
+
+// markEndBlock records the block the measured window ends at
+func (b *Benchmark) markEndBlock() {
+	b.blocks.end, b.blocks.endKnown = b.latestBlock("end")
+}
+
+// printBlockRangeReport prints the block range and, with confirmation tracking, txs per block
+func (b *Benchmark) printBlockRangeReport() {
+	produced, ok := b.blocks.produced()
+	if !ok {
+		return
+	}
+
+	fmt.Printf("\n📦 Block Range:\n")
+	fmt.Printf("  Blocks:             [%d, %d] (%d produced)\n", b.blocks.start, b.blocks.end, produced)
+	if b.watcher != nil && produced > 0 {
+		fmt.Printf("  Confirmed/Block:    %.2f transactions\n", float64(b.windowConfirmed)/float64(produced))
+	}
+}
+
+// blockRangeResults fills the block range fields of the results
+func (b *Benchmark) blockRangeResults(results *Results) {
+	produced, ok := b.blocks.produced()
+	if !ok {
+		return
+	}
+
+	results.StartBlock = b.blocks.start
+	results.EndBlock = b.blocks.end
+	results.BlocksProduced = produced
+	if b.watcher != nil && produced > 0 {
+		results.ConfirmedPerBlock = float64(b.windowConfirmed) / float64(produced)
+	}
+}
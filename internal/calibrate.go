@@ -0,0 +1,150 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// A calibration run estimates the node's sustainable TPS: short sub-runs at a rising target
+// rate (calibrate_start_tps, times calibrate_step_factor each step) until a step's error rate
+// or p99 latency crosses its threshold, or the node stops keeping up with the target.
+
+// A step keeps up when it achieves at least this share of its target rate
+const calibrationMinAchieved = 0.9
+
+// Suggested target as a share of the estimate, leaving headroom for longer runs
+const calibrationHeadroom = 0.8
+
+// CalibrationStep is the outcome of one sub-run
+type CalibrationStep struct {
+	TargetTPS    float64 `json:"target_tps"`
+	AchievedTPS  float64 `json:"achieved_tps"`
+	ErrorRate    float64 `json:"error_rate"` // Percent of attempts that failed
+	P99LatencyMs int64   `json:"p99_latency_ms"`
+	Passed       bool    `json:"passed"`
+	Reason       string  `json:"reason,omitempty"` // Why the step failed
+	Error        string  `json:"error,omitempty"`  // The sub-run could not complete
+}
+
+// CalibrationResults is the summary written to output_file by -calibrate
+type CalibrationResults struct {
+	Steps        []CalibrationStep `json:"steps"`
+	EstimatedTPS float64           `json:"estimated_sustainable_tps"` // Achieved TPS of the last passing step (0 if none passed)
+	SuggestedTPS float64           `json:"suggested_target_tps"`      // Estimate with headroom, for tps_schedule
+	Limited      bool              `json:"limited"`                   // A threshold was crossed (false: ran out of steps)
+}
+
+// CalibrationStepConfig returns the config of the sub-run at target TPS: a constant
+// tps_schedule for calibrate_step_seconds, no warmup, results in a numbered file
+func (c *Config) CalibrationStepConfig(target float64, step int) *Config {
+	stepConfig := *c
+	stepConfig.TPSSchedule = []TPSPoint{{At: 0, TPS: target}}
+	stepConfig.TargetBlockUtilization = 0
+	stepConfig.DurationSeconds = c.GetCalibrateStepSeconds()
+	stepConfig.WarmupDuration = 0
+	stepConfig.SoakMode = false
+	stepConfig.UntilInterrupt = false
+	stepConfig.TotalTxLimit = 0
+	stepConfig.MinTPS = 0
+	stepConfig.StopOnStability = false // Each step is judged on its full window
+	stepConfig.OutputFile = IndexedFilename(c.OutputFile, step)
+	stepConfig.TxHashLogFile = IndexedFilename(c.TxHashLogFile, step)
+	stepConfig.StreamFile = IndexedFilename(c.StreamFile, step)
+	stepConfig.PrometheusFile = IndexedFilename(c.PrometheusFile, step)
+	stepConfig.PushgatewayURL = ""
+	stepConfig.InfluxFile = IndexedFilename(c.InfluxFile, step)
+	stepConfig.PhaseTraceFile = IndexedFilename(c.PhaseTraceFile, step)
+	stepConfig.InfluxURL = ""
+	return &stepConfig
+}
+
+// CalibrationTargets returns the target rates to try, in order
+func (c *Config) CalibrationTargets() []float64 {
+	targets := make([]float64, c.GetCalibrateMaxSteps())
+	rate := c.GetCalibrateStartTPS()
+	for i := range targets {
+		targets[i] = math.Round(rate)
+		rate *= c.GetCalibrateStepFactor()
+	}
+	return targets
+}
+
+// EvaluateCalibrationStep judges one sub-run against the calibration thresholds
+func (c *Config) EvaluateCalibrationStep(target float64, results *Results) CalibrationStep {
+	step := CalibrationStep{
+		TargetTPS:    target,
+		AchievedTPS:  results.AvgSubmittedTPS,
+		ErrorRate:    100 - results.RPCAcceptRate,
+		P99LatencyMs: results.P99LatencyMs,
+	}
+	if results.TotalSubmitted+results.TotalErrors == 0 {
+		step.ErrorRate = 0
+	}
+
+	var reasons []string
+	if step.ErrorRate > c.GetCalibrateMaxErrorPercent() {
+		reasons = append(reasons, fmt.Sprintf("error rate %.1f%% > %g%%", step.ErrorRate, c.GetCalibrateMaxErrorPercent()))
+	}
+	if step.P99LatencyMs > c.GetCalibrateMaxP99Ms() {
+		reasons = append(reasons, fmt.Sprintf("p99 %dms > %dms", step.P99LatencyMs, c.GetCalibrateMaxP99Ms()))
+	}
+	if step.AchievedTPS < calibrationMinAchieved*target {
+		reasons = append(reasons, fmt.Sprintf("achieved %.0f%% of target", step.AchievedTPS/target*100))
+	}
+	step.Passed = len(reasons) == 0
+	step.Reason = strings.Join(reasons, ", ")
+	return step
+}
+
+// NewCalibrationResults derives the estimate from the steps run so far
+func NewCalibrationResults(steps []CalibrationStep) *CalibrationResults {
+	results := &CalibrationResults{Steps: steps}
+	for _, step := range steps {
+		if !step.Passed {
+			results.Limited = true
+			break
+		}
+		results.EstimatedTPS = step.AchievedTPS
+	}
+	results.SuggestedTPS = math.Floor(results.EstimatedTPS * calibrationHeadroom)
+	return results
+}
+
+// Save writes the calibration summary, as gob for a .bin extension and JSON otherwise
+func (r *CalibrationResults) Save(filename string) error {
+	return writeResultsFile(filename, r)
+}
+
+// Print shows every step and the estimate with a suggested config
+func (r *CalibrationResults) Print() {
+	fmt.Println("\n" + strings.Repeat("=", 70))
+	fmt.Println("🎯 CALIBRATION")
+	fmt.Println(strings.Repeat("=", 70))
+	fmt.Printf("  %-10s | %-12s | %-10s | %-8s | %s\n", "Target", "Achieved", "Errors", "p99", "Result")
+	for _, step := range r.Steps {
+		result := "✅ ok"
+		switch {
+		case step.Error != "":
+			result = "❌ " + step.Error
+		case !step.Passed:
+			result = "❌ " + step.Reason
+		}
+		fmt.Printf("  %-10.0f | %-12.2f | %-10s | %-8s | %s\n", step.TargetTPS, step.AchievedTPS,
+			fmt.Sprintf("%.1f%%", step.ErrorRate), fmt.Sprintf("%dms", step.P99LatencyMs), result)
+	}
+
+	fmt.Println()
+	switch {
+	case r.EstimatedTPS == 0:
+		fmt.Println("  ⚠️  Even the first step failed; lower calibrate_start_tps or check the setup")
+		return
+	case !r.Limited:
+		fmt.Printf("  %-20s≥ %.2f TPS (every step passed; raise calibrate_max_steps to go further)\n", "Sustainable TPS:", r.EstimatedTPS)
+	default:
+		fmt.Printf("  %-20s~%.2f TPS\n", "Sustainable TPS:", r.EstimatedTPS)
+	}
+	fmt.Printf("  %-20s%.0f TPS (%.0f%% of the estimate)\n", "Suggested Target:", r.SuggestedTPS, calibrationHeadroom*100)
+	fmt.Printf("\n  Suggested config:\n")
+	fmt.Printf("    \"tps_schedule\": [{\"at\": 0, \"tps\": %.0f}]\n", r.SuggestedTPS)
+}
@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// AccountClaim is written to the claim directory by every running load generator
+// so that overlapping account sets across shards are caught before they collide on nonces.
+type AccountClaim struct {
+	Host      string   `json:"host"`
+	PID       int      `json:"pid"`
+	Started   string   `json:"started"`
+	Addresses []string `json:"addresses"`
+}
+
+// ClaimAccounts registers the accounts in dir (a directory shared by all load generators)
+// and fails if another process has already claimed any of them.
+// The returned release function removes the claim; call it when the run ends.
+func ClaimAccounts(dir string, accounts []*AccountSender) (func(), error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create claim directory: %v", err)
+	}
+
+	host, _ := os.Hostname()
+	claim := AccountClaim{
+		Host:    host,
+		PID:     os.Getpid(),
+		Started: time.Now().Format(time.RFC3339),
+	}
+	ours := make(map[string]bool, len(accounts))
+	for _, account := range accounts {
+		address := account.from.Hex()
+		claim.Addresses = append(claim.Addresses, address)
+		ours[strings.ToLower(address)] = true
+	}
+
+	data, err := json.MarshalIndent(claim, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode claim: %v", err)
+	}
+
+	// Write our claim first, then look at everyone else's: if two processes start at
+	// the same moment both see the overlap and both refuse, which is the safe outcome.
+	claimFile := filepath.Join(dir, fmt.Sprintf("%s-%d.claim.json", host, claim.PID))
+	if err := os.WriteFile(claimFile, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write claim file: %v", err)
+	}
+	release := func() {
+		os.Remove(claimFile)
+	}
+
+	others, err := filepath.Glob(filepath.Join(dir, "*.claim.json"))
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to list claim files: %v", err)
+	}
+
+	for _, other := range others {
+		if other == claimFile {
+			continue
+		}
+		otherData, err := os.ReadFile(other)
+		if err != nil {
+			continue // Removed by a process that just finished
+		}
+		var otherClaim AccountClaim
+		if err := json.Unmarshal(otherData, &otherClaim); err != nil {
+			fmt.Printf("⚠️  Ignoring unreadable claim file %s: %v\n", other, err)
+			continue
+		}
+
+		var overlap []string
+		for _, address := range otherClaim.Addresses {
+			if ours[strings.ToLower(address)] {
+				overlap = append(overlap, address)
+			}
+		}
+		if len(overlap) > 0 {
+			release()
+			return nil, fmt.Errorf("%d accounts (first: %s) are already claimed by %s (pid %d, started %s); "+
+				"use disjoint key shards, or delete %s if that run is no longer alive",
+				len(overlap), overlap[0], otherClaim.Host, otherClaim.PID, otherClaim.Started, other)
+		}
+	}
+
+	return release, nil
+}
@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ChainRun is one benchmark of a multi-chain comparison
+type ChainRun struct {
+	Label   string   `json:"label"`
+	RPCURL  string   `json:"rpc_url"`
+	Error   string   `json:"error,omitempty"` // Set when the run could not complete
+	Results *Results `json:"results,omitempty"`
+}
+
+// ComparisonRow holds the headline numbers of one chain, relative to the first successful run
+type ComparisonRow struct {
+	Label             string  `json:"label"`
+	ChainID           int64   `json:"chain_id,omitempty"`
+	HeadlineTPS       float64 `json:"headline_tps"` // Submitted or confirmed, per headline_metric
+	AvgSubmittedTPS   float64 `json:"average_submitted_tps"`
+	PeakSubmittedTPS  uint64  `json:"peak_submitted_tps"`
+	AvgConfirmedTPS   float64 `json:"average_confirmed_tps,omitempty"`
+	P50LatencyMs      int64   `json:"p50_latency_ms"`
+	P95LatencyMs      int64   `json:"p95_latency_ms"`
+	RPCAcceptRate     float64 `json:"rpc_accept_rate"`
+	TPSVsBaseline     float64 `json:"tps_vs_baseline_percent"`     // Headline TPS relative to the baseline (100 = equal)
+	LatencyVsBaseline float64 `json:"latency_vs_baseline_percent"` // P50 latency relative to the baseline
+}
+
+// ComparisonResults is the combined output of benchmarking several chains in sequence
+type ComparisonResults struct {
+	Timestamp string          `json:"timestamp"`
+	Baseline  string          `json:"baseline,omitempty"` // Label of the run the others are compared with
+	Summary   []ComparisonRow `json:"summary"`
+	Runs      []ChainRun      `json:"runs"`
+}
+
+// NewComparison builds the comparison summary. The first run with results is the baseline.
+func NewComparison(runs []ChainRun) *ComparisonResults {
+	c := &ComparisonResults{Timestamp: time.Now().Format(time.RFC3339), Runs: runs}
+
+	var baseline *Results
+	for _, run := range runs {
+		if run.Results == nil {
+			continue
+		}
+		r := run.Results
+		if baseline == nil {
+			baseline = r
+			c.Baseline = run.Label
+		}
+		c.Summary = append(c.Summary, ComparisonRow{
+			Label:             run.Label,
+			ChainID:           r.ChainID,
+			HeadlineTPS:       r.HeadlineTPS,
+			AvgSubmittedTPS:   r.AvgSubmittedTPS,
+			PeakSubmittedTPS:  r.PeakSubmittedTPS,
+			AvgConfirmedTPS:   r.AvgConfirmedTPS,
+			P50LatencyMs:      r.P50LatencyMs,
+			P95LatencyMs:      r.P95LatencyMs,
+			RPCAcceptRate:     r.RPCAcceptRate,
+			TPSVsBaseline:     percentOf(r.HeadlineTPS, baseline.HeadlineTPS),
+			LatencyVsBaseline: percentOf(float64(r.P50LatencyMs), float64(baseline.P50LatencyMs)),
+		})
+	}
+	return c
+}
+
+// percentOf returns value as a percentage of base (0 when base is 0)
+func percentOf(value, base float64) float64 {
+	if base == 0 {
+		return 0
+	}
+	return value / base * 100
+}
+
+// Print shows the comparison table
+func (c *ComparisonResults) Print() {
+	fmt.Println("\n" + strings.Repeat("=", 70))
+	fmt.Println("CHAIN COMPARISON")
+	fmt.Println(strings.Repeat("=", 70))
+
+	fmt.Printf("\n%-24s | %-9s | %-7s | %-8s | %-8s | %-8s\n", "Chain", "TPS", "vs Base", "Peak TPS", "P50 Lat", "vs Base")
+	fmt.Println(strings.Repeat("-", 80))
+	for _, row := range c.Summary {
+		fmt.Printf("%-24s | %-9.2f | %-7s | %-8d | %-8s | %-8s\n",
+			truncateLabel(row.Label, 24), row.HeadlineTPS, fmt.Sprintf("%.0f%%", row.TPSVsBaseline), row.PeakSubmittedTPS,
+			fmt.Sprintf("%dms", row.P50LatencyMs), fmt.Sprintf("%.0f%%", row.LatencyVsBaseline))
+	}
+	for _, run := range c.Runs {
+		if run.Error != "" {
+			fmt.Printf("%-24s | ❌ %s\n", truncateLabel(run.Label, 24), run.Error)
+		}
+	}
+	if c.Baseline != "" {
+		fmt.Printf("\n(\"vs Base\" is relative to %s)\n", c.Baseline)
+	}
+}
+
+// truncateLabel shortens a label to fit a table column
+func truncateLabel(label string, width int) string {
+	if len(label) <= width {
+		return label
+	}
+	return label[:width-3] + "..."
+}
+
+// Save writes the combined results, as gob for a .bin extension and JSON otherwise
+func (c *ComparisonResults) Save(filename string) error {
+	return writeResultsFile(filename, c)
+}
+
+// ChainLabel names a run in a comparison: its run_label, else the RPC host
+func ChainLabel(config *Config) string {
+	if config.RunLabel != "" {
+		return config.RunLabel
+	}
+	if u, err := url.Parse(config.RPCURL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return config.RPCURL
+}
+
+// IndexedFilename inserts .<index> before the extension ("results.json" → "results.2.json"),
+// so the per-chain output files of a comparison do not overwrite each other
+func IndexedFilename(filename string, index int) string {
+	if filename == "" {
+		return ""
+	}
+	ext := filepath.Ext(filename)
+	return fmt.Sprintf("%s.%d%s", strings.TrimSuffix(filename, ext), index, ext)
+}
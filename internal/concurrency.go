@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+)
+
+// ConcurrencyStats contrasts the configured concurrency with what the run actually used
+type ConcurrencyStats struct {
+	ConfiguredAccounts          int    `json:"configured_accounts"`
+	ConfiguredSendersPerAccount int    `json:"configured_senders_per_account"`
+	ActiveAccounts              int    `json:"active_accounts"`             // Accounts with at least one worker
+	SendersPerActiveAccount     int    `json:"senders_per_account"`         // Workers per active account
+	TotalWorkers                int    `json:"total_workers"`               // Workers started
+	WorkersAtEnd                int    `json:"workers_at_end"`              // Workers still sending when the window closed
+	SkippedAccounts             int    `json:"skipped_accounts"`            // Dropped for insufficient balance
+	RecoveredPanics             uint64 `json:"recovered_panics,omitempty"`  // Worker panics recovered (and restarted)
+	WorkersCancelled            int    `json:"workers_cancelled,omitempty"` // Workers still running at the shutdown timeout
+	WorkersAbandoned            int    `json:"workers_abandoned,omitempty"` // Of those, workers that had not returned when the report started
+
+	Rotation *RotationStats `json:"rotation,omitempty"` // Rotating pool (max_workers only)
+}
+
+// concurrencyStats resolves the effective concurrency of the run
+func (b *Benchmark) concurrencyStats() ConcurrencyStats {
+	stats := ConcurrencyStats{
+		ConfiguredAccounts:          b.config.NumAccounts,
+		ConfiguredSendersPerAccount: b.config.ConcurrentSendersPerAccount,
+		TotalWorkers:                b.totalWorkers(),
+		WorkersAtEnd:                int(atomic.LoadInt64(&b.workersAtEnd)),
+		SkippedAccounts:             b.skippedAccounts,
+		RecoveredPanics:             b.recoveredPanics(),
+		WorkersCancelled:            int(atomic.LoadInt64(&b.workersCancelled)),
+		WorkersAbandoned:            int(atomic.LoadInt64(&b.workersAbandoned)),
+		Rotation:                    b.rotationStats(),
+	}
+	for i := range b.accounts {
+		if senders := b.sendersForAccount(i); senders > 0 {
+			stats.ActiveAccounts++
+			stats.SendersPerActiveAccount = senders
+		}
+	}
+	return stats
+}
+
+// printConcurrencyReport prints configured against effective concurrency
+func (b *Benchmark) printConcurrencyReport() {
+	stats := b.concurrencyStats()
+
+	configuredSenders := fmt.Sprintf("%d", stats.ConfiguredSendersPerAccount)
+	if stats.ConfiguredSendersPerAccount <= 0 {
+		configuredSenders = "unset (1)"
+	}
+
+	fmt.Printf("\n🧵 Concurrency:\n")
+	fmt.Printf("  Configured:         %d accounts × %s senders/account\n", stats.ConfiguredAccounts, configuredSenders)
+	fmt.Printf("  CPUs:               %d logical, GOMAXPROCS %d\n", runtime.NumCPU(), runtime.GOMAXPROCS(0))
+	if r := stats.Rotation; r != nil {
+		fmt.Printf("  Effective:          %d rotating workers over %d accounts (max_workers, every %gs, %d reassignments)\n",
+			r.Workers, r.Accounts, r.IntervalSeconds, r.Reassignments)
+		fmt.Printf("  Per-Account Sends:  min %d / max %d", r.MinSent, r.MaxSent)
+		if r.UnusedAccounts > 0 {
+			fmt.Printf(" (%d accounts never sent; lengthen the run or shorten account_rotation_seconds)", r.UnusedAccounts)
+		}
+		fmt.Println()
+	} else {
+		fmt.Printf("  Effective:          %d active accounts × %d senders = %d workers\n",
+			stats.ActiveAccounts, stats.SendersPerActiveAccount, stats.TotalWorkers)
+	}
+	if idle := len(b.accounts) - stats.ActiveAccounts; idle > 0 && b.replay != nil {
+		fmt.Printf("  Idle:               %d accounts (no rows in workload_file)\n", idle)
+	} else if idle > 0 {
+		fmt.Printf("  Receive-Only:       %d accounts (fan-out recipients)\n", idle)
+	}
+	if stats.SkippedAccounts > 0 {
+		fmt.Printf("  Skipped:            %d accounts (insufficient balance)\n", stats.SkippedAccounts)
+	}
+	if stats.WorkersCancelled > 0 {
+		fmt.Printf("  ⚠️  Unclean Stop:     %d workers did not stop within %v (in-flight sends cancelled, %d never returned)\n",
+			stats.WorkersCancelled, b.config.GetShutdownTimeout(), stats.WorkersAbandoned)
+	}
+	if stats.RecoveredPanics > 0 {
+		fmt.Printf("  ⚠️  Recovered Panics: %d (workers were restarted; see the log for the stack trace)\n", stats.RecoveredPanics)
+	}
+	// (with total_tx_limit, workers leave as soon as the limit is reached)
+	if stats.WorkersAtEnd < stats.TotalWorkers && !b.limitReached() {
+		fmt.Printf("  ⚠️  Only %d of %d workers were still sending when the window closed\n",
+			stats.WorkersAtEnd, stats.TotalWorkers)
+	}
+}
@@ -2,35 +2,441 @@ package internal
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
 	"os"
+	"reflect"
+	"strings"
 	"time"
 )
 
 type Config struct {
 	// RPC Configuration
-	RPCURL string `json:"rpc_url"`
+	RPCURL          string   `json:"rpc_url"`
+	RPCURLs         []string `json:"rpc_urls"`         // Optional: more endpoints; each account is pinned to one of rpc_url + rpc_urls for the whole run
+	MaxConnections  int      `json:"max_connections"`  // HTTP connection pool size
+	ChainID         int64    `json:"chain_id"`         // Optional: sign for this chain ID instead of the node's eth_chainId (0 = use the node's)
+	StartupAttempts int      `json:"startup_attempts"` // Tries for the initial chain ID request before giving up (default 5)
+
+	// TLS for https RPC endpoints (default: Go's standard certificate verification)
+	TLSCACertFile         string `json:"tls_ca_cert_file"`         // Optional: PEM CA certificate to trust in addition to the system roots
+	TLSInsecureSkipVerify bool   `json:"tls_insecure_skip_verify"` // Skip certificate verification entirely (lab nodes only)
+
+	// Transport-level retries of 5xx/connection errors and circuit breaking (off by default)
+	RPCRetryAttempts        int `json:"rpc_retry_attempts"`               // Tries per HTTP request, including the first (0/1 = no retries)
+	RPCRetryBackoff         int `json:"rpc_retry_backoff_ms"`             // Base backoff, doubled per retry and jittered (default 50)
+	CircuitBreakerThreshold int `json:"circuit_breaker_threshold"`        // Consecutive failures that open an endpoint's circuit (0 = off)
+	CircuitBreakerCooldown  int `json:"circuit_breaker_cooldown_seconds"` // How long an open circuit rejects requests (default 5)
 
 	// Benchmark Settings
-	NumAccounts     int `json:"num_accounts"`
-	DurationSeconds int `json:"duration_seconds"` // Duration in seconds
+	NumAccounts        int    `json:"num_accounts"`
+	DurationSeconds    int    `json:"duration_seconds"`             // Duration in seconds
+	UntilInterrupt     bool   `json:"until_interrupt"`              // Ignore duration_seconds and run until Ctrl+C
+	WarmupDuration     int    `json:"warmup_duration_seconds"`      // Sending before measurement starts (excluded from metrics)
+	ActivateAccounts   bool   `json:"activate_accounts"`            // Send one self-transfer per account and wait for it before the run
+	ActivationTimeout  int    `json:"activation_timeout_seconds"`   // How long to wait for activation transactions (default 30)
+	TotalTxLimit       int    `json:"total_tx_limit"`               // Stop after this many submitted txs (0 = no limit; duration still applies)
+	MaxSpend           string `json:"max_spend_u2u"`                // Stop once estimated spend (value + gas) reaches this, in U2U or with a unit (empty = no cap)
+	StartupGracePeriod int    `json:"startup_grace_period_seconds"` // Abort if nothing succeeds within this time (0 = never)
+	Seed               int64  `json:"seed"`                         // Master seed for all worker RNGs (0 = time-based; the seed used is in the results)
 
 	// Transaction Settings
-	GasLimit       uint64 `json:"gas_limit"`
-	TransferAmount string `json:"transfer_amount_wei"` // in wei
+	GasLimit              uint64    `json:"gas_limit"`
+	GasLimitJitterPercent int       `json:"gas_limit_jitter_percent"` // Randomize each tx's gas limit by up to ±N% (never below 21000)
+	TransferAmount        string    `json:"transfer_amount_wei"`      // in wei
+	FixedGasPriceWei      string    `json:"fixed_gas_price_wei"`      // Optional: fixed gas price in wei or with a unit ("5 gwei"), fee cap with eip1559; empty = node suggestion
+	EIP1559               bool      `json:"eip1559"`                  // Send dynamic-fee (type 2) transactions
+	TipStrategy           string    `json:"tip_strategy"`             // eip1559 only: "suggested" (default), "fixed" or "percentile"
+	FixedTipWei           string    `json:"fixed_tip_wei"`            // Tip for the "fixed" strategy, in wei or with a unit ("2 gwei")
+	TipPercentile         float64   `json:"tip_percentile"`           // Reward percentile for the "percentile" strategy (default 50)
+	GasPriceMultipliers   []float64 `json:"gas_price_multipliers"`    // Optional: split accounts into groups priced at these multiples of the gas price (and tip)
+
+	// Workload
+	Workload            string             `json:"workload"`              // "transfer" (default), "erc20", "deploy", "call", "read" or "replay"
+	WorkloadFile        string             `json:"workload_file"`         // "replay": CSV of senderIndex,recipientAddress,valueWei[,gasLimit]
+	ERC20TokenAddress   string             `json:"erc20_token_address"`   // Token contract for the "erc20" workload
+	ERC20Amount         string             `json:"erc20_amount"`          // Token base units per "erc20" transfer (default 1)
+	DeployBytecode      string             `json:"deploy_bytecode"`       // Init code for the "deploy" workload (hex; default deploys an empty contract)
+	CallContractAddress string             `json:"call_contract_address"` // Target of the "call" workload
+	CallFunction        string             `json:"call_function"`         // "call": ABI signature ("increment(uint256)") or 4-byte selector ("0xd09de08a")
+	CallArgs            []string           `json:"call_args"`             // "call": one template per argument; literals or {counter}, {sender}, {recipient}
+	CallValueWei        string             `json:"call_value_wei"`        // "call": value sent with each call (default 0)
+	WorkloadMix         map[string]float64 `json:"workload_mix"`          // Optional: weights per workload ({"transfer": 3, "call": 1}); each tx picks one, overrides workload
+
+	// Transfer Pattern
+	TransferPattern   string `json:"transfer_pattern"`    // "round-robin" (default) or "fan-out"
+	FanOutSenders     int    `json:"fan_out_senders"`     // Fan-out: number of distributor accounts (default 1)
+	FanOutConcurrency int    `json:"fan_out_concurrency"` // Fan-out: senders per distributor (default: total worker budget / distributors)
 
 	// Account Management
-	PrivateKeysFile string `json:"private_keys_file"`
+	PrivateKeysFile         string   `json:"private_keys_file"`
+	GenesisFile             string   `json:"genesis_file"`              // Optional: use only the accounts prefunded in this genesis alloc (keys embedded or from private_keys_file)
+	RemoteSignerURL         string   `json:"remote_signer_url"`         // Optional: sign via a Clef-compatible account_signTransaction endpoint instead of private_keys_file
+	RemoteSignerAccounts    []string `json:"remote_signer_accounts"`    // Sender addresses held by the remote signer (empty = the signer's account_list)
+	MinBalanceWei           string   `json:"min_balance_wei"`           // Optional: fixed minimum balance per account (overrides estimate)
+	MinBalanceTxCount       int      `json:"min_balance_tx_count"`      // Transactions per account to budget for when estimating the minimum
+	NonceOffset             int      `json:"nonce_offset"`              // Added to each account's starting nonce (testing queued txs)
+	NoPreflight             bool     `json:"no_preflight"`              // Skip the nonce, balance and code reads and the balance check (forks, special node modes)
+	StartNonce              uint64   `json:"start_nonce"`               // Starting nonce of every account with no_preflight (default 0)
+	NonceReconcile          string   `json:"nonce_reconcile"`           // Leftover txpool txs at start: "ignore" (default), "wait" or "skip-ahead"
+	NonceReconcileTimeout   int      `json:"nonce_reconcile_timeout"`   // Seconds to wait in "wait" mode (default 60)
+	FailOnContractSenders   bool     `json:"fail_on_contract_senders"`  // Abort (instead of warn) when a sender address has code
+	SkipUnderfundedAccounts bool     `json:"skip_underfunded_accounts"` // Drop underfunded accounts instead of aborting the run
+	ClaimDir                string   `json:"claim_dir"`                 // Optional: shared directory where sharded runs claim their accounts to detect overlaps
+	DisperseContractAddress string   `json:"disperse_contract_address"` // Optional: cmd/fund pays accounts in batches through this Disperse contract
+	FaucetURL               string   `json:"faucet_url"`                // Optional: POST underfunded addresses to this testnet faucet before the run
+	FaucetRequestTemplate   string   `json:"faucet_request_template"`   // Faucet request body; {address} is replaced (default {"address":"{address}"})
+	FaucetContentType       string   `json:"faucet_content_type"`       // Content-Type of faucet requests (default application/json)
+	FaucetTimeout           int      `json:"faucet_timeout_seconds"`    // Seconds to wait for faucet payouts to arrive (default 120)
 
 	// Reporting
-	ReportInterval int    `json:"report_interval_seconds"`
-	OutputFile     string `json:"output_file"`
+	ReportInterval       int     `json:"report_interval_seconds"`
+	ExcludeTailInterval  bool    `json:"exclude_tail_interval"` // Leave the last (draining) interval out of the headline TPS/latency numbers
+	TPSHistogram         bool    `json:"tps_histogram"`         // Print an ASCII histogram of per-interval TPS in the final report
+	LatencyPrecision     string  `json:"latency_precision"`     // Rounding of displayed latencies: "ms" (default), "us" or "ns"
+	HeadlineMetric       string  `json:"headline_metric"`       // Primary TPS result: "submitted" (default) or "confirmed" (needs track_confirmations)
+	MinTPS               float64 `json:"min_tps"`               // Fail the run (non-zero exit) when the headline TPS is below this (0 = no gate)
+	OutputFile           string  `json:"output_file"`
+	TrackConfirmations   bool    `json:"track_confirmations"`         // Scan new blocks to count confirmed transactions
+	ConfirmationPollMs   int     `json:"confirmation_poll_ms"`        // How often to check for new blocks
+	ConfirmationDepth    int     `json:"confirmation_depth"`          // Blocks required on top of a tx's block before it counts as confirmed (0 = as soon as included)
+	TrackReverts         bool    `json:"track_reverts"`               // Fetch receipts of confirmed txs to count reverts (needs track_confirmations)
+	TrackFinality        bool    `json:"track_finality"`              // Measure submission-to-finalized latency via the "finalized" block tag (needs track_confirmations)
+	DrainTimeout         int     `json:"drain_timeout_seconds"`       // After the send window, keep counting confirmations for up to this long (needs track_confirmations)
+	StopOnStability      bool    `json:"stop_on_stability"`           // End the run once the confirmed TPS holds steady (needs track_confirmations)
+	StabilityBandPercent float64 `json:"stability_band_percent"`      // Max deviation (%) of an interval's confirmed TPS from the mean of the window (default 5)
+	StabilityIntervals   int     `json:"stability_intervals"`         // Consecutive report intervals that must stay within the band (default 5)
+	RevertWarnPercent    float64 `json:"revert_warn_percent"`         // Flag the run when reverts exceed this share of confirmed txs
+	TxHashLogFile        string  `json:"tx_hash_log_file"`            // Optional: record submitted tx hashes for cmd/verify
+	StreamFile           string  `json:"stream_file"`                 // Optional: append one JSON line of live metrics per report interval
+	PerAccountTimeSeries bool    `json:"per_account_time_series"`     // Record per-account sent/errors per interval into <output_file>_accounts.json
+	BalanceDeltaReport   bool    `json:"balance_delta_report"`        // Read every account's balance before and after the run and report the changes
+	TrackPropagation     bool    `json:"track_propagation"`           // Look up sampled txs on the other rpc_urls endpoints to measure propagation
+	PropagationSample    float64 `json:"propagation_sample_percent"`  // Share of sends to track (default 1)
+	PropagationPollMs    int     `json:"propagation_poll_ms"`         // How often each endpoint is polled for the tracked txs (default 100)
+	PropagationTimeout   int     `json:"propagation_timeout_seconds"` // A tx not seen on an endpoint within this long counts as missing (default 30)
+	ErrorSamples         int     `json:"error_samples"`               // Distinct error messages kept for the report (default 5)
+	RunLabel             string  `json:"run_label"`                   // Optional: name for this run, added to the results and exported metrics
+	PrometheusFile       string  `json:"prometheus_file"`             // Optional: also write the final results in Prometheus text format
+	PushgatewayURL       string  `json:"pushgateway_url"`             // Optional: push the final results to this Prometheus pushgateway
+	PushgatewayJob       string  `json:"pushgateway_job"`             // Job label for pushed metrics (default "u2u_benchmark")
+	InfluxFile           string  `json:"influx_file"`                 // Optional: write per-interval and final metrics in InfluxDB line protocol
+	InfluxURL            string  `json:"influx_url"`                  // Optional: InfluxDB write endpoint to send the same points to at the end of the run
+	InfluxToken          string  `json:"influx_token"`                // Optional: API token for influx_url (sent as "Authorization: Token ...")
+	DebugRuntime         bool    `json:"debug_runtime"`               // Log goroutines, heap and GC pauses of the load generator
+	TracePhases          bool    `json:"trace_phases"`                // Report how long each phase of the invocation took (init, warmup, measure, drain, ...)
+	PhaseTraceFile       string  `json:"phase_trace_file"`            // Optional: write the phases as folded stacks for flame graph tools
 
 	// Advanced
-	MaxRetries int `json:"max_retries"`
-	RetryDelay int `json:"retry_delay_ms"`
+	MaxRetries              int  `json:"max_retries"`
+	RetryDelay              int  `json:"retry_delay_ms"`
+	SendBackoffBaseMs       int  `json:"send_backoff_base_ms"`        // Wait after the first failed send attempt, doubled per failure in a row (default 1)
+	SendBackoffMaxMs        int  `json:"send_backoff_max_ms"`         // Cap on that wait (default 200)
+	CountAlreadyKnownAsSent bool `json:"count_already_known_as_sent"` // Count "already known" responses as submitted (the tx is in the mempool)
+	NonceResyncThreshold    int  `json:"nonce_resync_threshold"`      // Re-read an account's nonce after this many consecutive nonce errors (default 20, -1 = never)
+	RetryRuns               int  `json:"retry_runs"`                  // Re-run the whole benchmark up to N times when no transaction succeeded at all
 
 	// Throughput optimization
-	ConcurrentSendersPerAccount int `json:"concurrent_senders_per_account"` // Number of parallel senders per account
+	ConcurrentSendersPerAccount int        `json:"concurrent_senders_per_account"` // Number of parallel senders per account
+	WarmCacheMode               bool       `json:"warm_cache_mode"`                // EXPERIMENTAL: reuse one pre-computed signature per worker (RPC upper bound only)
+	FairNonce                   bool       `json:"fair_nonce"`                     // Workers sharing an account submit in nonce order
+	MinAccountInterval          int        `json:"min_account_interval_ms"`        // Minimum time between two sends of one account, across its workers (0 = no limit)
+	MaxWorkers                  int        `json:"max_workers"`                    // Cap on sender workers; below the per-account total, a pool rotates over the accounts (0 = no cap)
+	AccountRotationSeconds      float64    `json:"account_rotation_seconds"`       // How long a rotating worker stays on one account (default 5)
+	MaxWorkerRestarts           int        `json:"max_worker_restarts"`            // Restarts of a worker after a recovered panic (default 10, -1 = unlimited)
+	ShutdownTimeout             int        `json:"shutdown_timeout_seconds"`       // Wait for workers to stop before cancelling their in-flight sends (default 10)
+	GoMaxProcs                  int        `json:"gomaxprocs"`                     // runtime.GOMAXPROCS of the load generator (0 = Go default, the logical CPU count)
+	TPSSchedule                 []TPSPoint `json:"tps_schedule"`                   // Optional: target rate points {at, tps}, interpolated over the measured window
+	TargetBlockUtilization      float64    `json:"target_block_utilization"`       // Optional: block gas use (%) to hold by adjusting the send rate (needs track_confirmations)
+	UtilizationStartTPS         float64    `json:"utilization_start_tps"`          // Rate the utilization controller starts from (default 100)
+	UtilizationMaxTPS           float64    `json:"utilization_max_tps"`            // Cap on the controller's rate (0 = no cap)
+	UtilizationAdjustSeconds    float64    `json:"utilization_adjust_seconds"`     // How often the controller adjusts the rate (default 2)
+	BlockGasLimit               uint64     `json:"block_gas_limit"`                // Gas per block to measure utilization against (default: each block's gas limit)
+
+	// Soak testing
+	SoakMode               bool    `json:"soak_mode"`                    // Run until stopped, ignoring duration_seconds
+	SoakHealthInterval     int     `json:"soak_health_interval_seconds"` // How often to log a health summary
+	SoakErrorRateThreshold float64 `json:"soak_error_rate_threshold"`    // Error rate (%) that marks an interval unhealthy
+	SoakMaxBadIntervals    int     `json:"soak_max_unhealthy_intervals"` // Consecutive unhealthy intervals before stopping
+
+	// Calibration (-calibrate)
+	CalibrateStartTPS        float64 `json:"calibrate_start_tps"`         // Target rate of the first step (default 50)
+	CalibrateStepFactor      float64 `json:"calibrate_step_factor"`       // Target multiplier per step (default 1.5)
+	CalibrateStepSeconds     int     `json:"calibrate_step_seconds"`      // Length of each step (default 10)
+	CalibrateMaxSteps        int     `json:"calibrate_max_steps"`         // Steps before giving up on finding the limit (default 12)
+	CalibrateMaxErrorPercent float64 `json:"calibrate_max_error_percent"` // A step fails above this error rate (default 2)
+	CalibrateMaxP99Ms        int64   `json:"calibrate_max_p99_ms"`        // A step fails above this p99 send latency (default 2000)
+}
+
+// LatencyUnit returns the unit displayed latencies are rounded to (default 1ms)
+func (c *Config) LatencyUnit() (time.Duration, error) {
+	switch strings.ToLower(c.LatencyPrecision) {
+	case "", "ms":
+		return time.Millisecond, nil
+	case "us", "µs":
+		return time.Microsecond, nil
+	case "ns":
+		return time.Nanosecond, nil
+	default:
+		return 0, fmt.Errorf("unknown latency_precision %q (use \"ms\", \"us\" or \"ns\")", c.LatencyPrecision)
+	}
+}
+
+// TransferValue parses TransferAmount into wei
+func (c *Config) TransferValue() (*big.Int, error) {
+	value, err := ParseAmount(c.TransferAmount, "wei")
+	if err != nil {
+		return nil, fmt.Errorf("transfer_amount_wei: %v", err)
+	}
+	return value, nil
+}
+
+// Tip returns the dynamic-fee tip settings
+func (c *Config) Tip() TipSettings {
+	return TipSettings{
+		Strategy:   c.TipStrategy,
+		FixedTip:   c.FixedTipWei,
+		Percentile: c.TipPercentile,
+	}
+}
+
+// GetMaxConnections returns the connection pool size (default 2000)
+func (c *Config) GetMaxConnections() int {
+	if c.MaxConnections <= 0 {
+		return 2000
+	}
+	return c.MaxConnections
+}
+
+// GetConfirmationDepth returns the confirmation depth in blocks (negative values mean 0)
+func (c *Config) GetConfirmationDepth() uint64 {
+	if c.ConfirmationDepth <= 0 {
+		return 0
+	}
+	return uint64(c.ConfirmationDepth)
+}
+
+// GetStartupAttempts returns how often the initial connectivity calls are tried (default 5)
+func (c *Config) GetStartupAttempts() int {
+	if c.StartupAttempts <= 0 {
+		return 5
+	}
+	return c.StartupAttempts
+}
+
+// GetNonceResyncThreshold returns the nonce error streak that triggers a resync (default 20, negative = never)
+func (c *Config) GetNonceResyncThreshold() int {
+	if c.NonceResyncThreshold == 0 {
+		return 20
+	}
+	return c.NonceResyncThreshold
+}
+
+// GetAccountRotationInterval returns how long a rotating worker stays on one account (default 5s)
+func (c *Config) GetAccountRotationInterval() time.Duration {
+	if c.AccountRotationSeconds <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(c.AccountRotationSeconds * float64(time.Second))
+}
+
+// GetPropagationSamplePercent returns the share of sends tracked for propagation (default 1%)
+func (c *Config) GetPropagationSamplePercent() float64 {
+	if c.PropagationSample <= 0 {
+		return 1
+	}
+	return min(c.PropagationSample, 100)
+}
+
+// GetPropagationPollInterval returns how often endpoints are polled for tracked txs (default 100ms)
+func (c *Config) GetPropagationPollInterval() time.Duration {
+	if c.PropagationPollMs <= 0 {
+		return 100 * time.Millisecond
+	}
+	return time.Duration(c.PropagationPollMs) * time.Millisecond
+}
+
+// GetPropagationTimeout returns how long a tracked tx may take to reach an endpoint (default 30s)
+func (c *Config) GetPropagationTimeout() time.Duration {
+	if c.PropagationTimeout <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.PropagationTimeout) * time.Second
+}
+
+// GetSendBackoffBase returns the backoff after the first failed send attempt (default 1ms)
+func (c *Config) GetSendBackoffBase() time.Duration {
+	if c.SendBackoffBaseMs <= 0 {
+		return time.Millisecond
+	}
+	return time.Duration(c.SendBackoffBaseMs) * time.Millisecond
+}
+
+// GetSendBackoffMax returns the cap on the send backoff (default 200ms, at least the base)
+func (c *Config) GetSendBackoffMax() time.Duration {
+	limit := 200 * time.Millisecond
+	if c.SendBackoffMaxMs > 0 {
+		limit = time.Duration(c.SendBackoffMaxMs) * time.Millisecond
+	}
+	return max(limit, c.GetSendBackoffBase())
+}
+
+// GetUtilizationStartTPS returns the rate the utilization controller starts from (default 100)
+func (c *Config) GetUtilizationStartTPS() float64 {
+	if c.UtilizationStartTPS <= 0 {
+		return 100
+	}
+	return c.UtilizationStartTPS
+}
+
+// GetUtilizationAdjustInterval returns how often the utilization controller adjusts the rate (default 2s)
+func (c *Config) GetUtilizationAdjustInterval() time.Duration {
+	if c.UtilizationAdjustSeconds <= 0 {
+		return 2 * time.Second
+	}
+	return time.Duration(c.UtilizationAdjustSeconds * float64(time.Second))
+}
+
+// GetStabilityBand returns how far (%) an interval's confirmed TPS may stray from the mean with stop_on_stability (default 5)
+func (c *Config) GetStabilityBand() float64 {
+	if c.StabilityBandPercent <= 0 {
+		return 5
+	}
+	return c.StabilityBandPercent
+}
+
+// GetStabilityIntervals returns how many intervals must stay in the band with stop_on_stability (default 5, at least 2)
+func (c *Config) GetStabilityIntervals() int {
+	if c.StabilityIntervals <= 0 {
+		return 5
+	}
+	return max(c.StabilityIntervals, 2)
+}
+
+// GetSoakErrorRateThreshold returns the error rate (%) that marks a soak interval unhealthy (default 10)
+func (c *Config) GetSoakErrorRateThreshold() float64 {
+	if c.SoakErrorRateThreshold <= 0 {
+		return 10
+	}
+	return c.SoakErrorRateThreshold
+}
+
+// GetMaxWorkerRestarts returns how often a worker is restarted after a panic (default 10, negative = unlimited)
+func (c *Config) GetMaxWorkerRestarts() int {
+	if c.MaxWorkerRestarts == 0 {
+		return 10
+	}
+	return c.MaxWorkerRestarts
+}
+
+// GetShutdownTimeout returns how long to wait for workers at the end of the run (default 10s)
+func (c *Config) GetShutdownTimeout() time.Duration {
+	if c.ShutdownTimeout <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.ShutdownTimeout) * time.Second
+}
+
+// GetCalibrateStartTPS returns the target rate of the first calibration step (default 50)
+func (c *Config) GetCalibrateStartTPS() float64 {
+	if c.CalibrateStartTPS <= 0 {
+		return 50
+	}
+	return c.CalibrateStartTPS
+}
+
+// GetCalibrateStepFactor returns the target multiplier between calibration steps (default 1.5)
+func (c *Config) GetCalibrateStepFactor() float64 {
+	if c.CalibrateStepFactor <= 1 {
+		return 1.5
+	}
+	return c.CalibrateStepFactor
+}
+
+// GetCalibrateStepSeconds returns the length of one calibration step (default 10)
+func (c *Config) GetCalibrateStepSeconds() int {
+	if c.CalibrateStepSeconds <= 0 {
+		return 10
+	}
+	return c.CalibrateStepSeconds
+}
+
+// GetCalibrateMaxSteps returns the most calibration steps to run (default 12)
+func (c *Config) GetCalibrateMaxSteps() int {
+	if c.CalibrateMaxSteps <= 0 {
+		return 12
+	}
+	return c.CalibrateMaxSteps
+}
+
+// GetCalibrateMaxErrorPercent returns the error rate that fails a calibration step (default 2%)
+func (c *Config) GetCalibrateMaxErrorPercent() float64 {
+	if c.CalibrateMaxErrorPercent <= 0 {
+		return 2
+	}
+	return c.CalibrateMaxErrorPercent
+}
+
+// GetCalibrateMaxP99Ms returns the p99 latency that fails a calibration step (default 2000ms)
+func (c *Config) GetCalibrateMaxP99Ms() int64 {
+	if c.CalibrateMaxP99Ms <= 0 {
+		return 2000
+	}
+	return c.CalibrateMaxP99Ms
+}
+
+// GetErrorSamples returns how many distinct error messages to keep (default 5)
+func (c *Config) GetErrorSamples() int {
+	if c.ErrorSamples <= 0 {
+		return 5
+	}
+	return c.ErrorSamples
+}
+
+// GetFaucetRequestTemplate returns the faucet request body template (default a JSON object with the address)
+func (c *Config) GetFaucetRequestTemplate() string {
+	if c.FaucetRequestTemplate == "" {
+		return `{"address":"{address}"}`
+	}
+	return c.FaucetRequestTemplate
+}
+
+// GetFaucetContentType returns the Content-Type of faucet requests (default application/json)
+func (c *Config) GetFaucetContentType() string {
+	if c.FaucetContentType == "" {
+		return "application/json"
+	}
+	return c.FaucetContentType
+}
+
+// GetFaucetTimeout returns how long to wait for faucet payouts (default 120s)
+func (c *Config) GetFaucetTimeout() time.Duration {
+	if c.FaucetTimeout <= 0 {
+		return 120 * time.Second
+	}
+	return time.Duration(c.FaucetTimeout) * time.Second
+}
+
+// GetPushgatewayJob returns the job label for pushed metrics (default "u2u_benchmark")
+func (c *Config) GetPushgatewayJob() string {
+	if c.PushgatewayJob == "" {
+		return "u2u_benchmark"
+	}
+	return c.PushgatewayJob
+}
+
+// GetRevertWarnPercent returns the revert warning threshold (default 1%)
+func (c *Config) GetRevertWarnPercent() float64 {
+	if c.RevertWarnPercent <= 0 {
+		return 1.0
+	}
+	return c.RevertWarnPercent
+}
+
+// RunsUntilInterrupt reports whether the run ignores duration_seconds and ends on Ctrl+C
+func (c *Config) RunsUntilInterrupt() bool {
+	return c.UntilInterrupt || c.SoakMode
 }
 
 // GetDuration returns the duration as time.Duration
@@ -45,29 +451,89 @@ func LoadConfig(filename string) (*Config, error) {
 	}
 	defer file.Close()
 
+	// Unknown keys are errors: a misspelled setting would otherwise be silently ignored
 	config := &Config{}
 	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
 	err = decoder.Decode(config)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%s: %v%s", filename, err, unknownFieldHint(err))
 	}
 
 	return config, nil
 }
 
+// unknownFieldHint suggests the closest known config key for an unknown-field error
+func unknownFieldHint(err error) string {
+	const prefix = "json: unknown field "
+	msg := err.Error()
+	if !strings.HasPrefix(msg, prefix) {
+		return ""
+	}
+	unknown := strings.Trim(strings.TrimPrefix(msg, prefix), `"`)
+
+	best, bestDistance := "", len(unknown)/2+1 // Only suggest reasonably close matches
+	configType := reflect.TypeOf(Config{})
+	for i := 0; i < configType.NumField(); i++ {
+		key := strings.Split(configType.Field(i).Tag.Get("json"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+		if d := editDistance(strings.ToLower(unknown), key); d < bestDistance {
+			best, bestDistance = key, d
+		}
+	}
+	if best == "" {
+		return " (check the key names in the README's configuration table)"
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
 func DefaultConfig() *Config {
 	return &Config{
 		RPCURL:                      "https://rpc-nebulas-testnet.uniultra.xyz",
+		MaxConnections:              2000,
 		NumAccounts:                 10,
 		DurationSeconds:             60, // Duration in seconds
 		GasLimit:                    21000,
 		TransferAmount:              "1000000000000000", // 0.001 U2U
+		Workload:                    WorkloadTransfer,
+		TransferPattern:             PatternRoundRobin,
+		FanOutSenders:               1,
 		ReportInterval:              1,
 		OutputFile:                  "benchmark_results.json",
+		ConfirmationPollMs:          500,
+		RevertWarnPercent:           1.0,
 		MaxRetries:                  3,
 		RetryDelay:                  100,
 		PrivateKeysFile:             "test_keys.json",
+		MinBalanceTxCount:           50,
 		ConcurrentSendersPerAccount: 0, // parallel senders per account
+		StartupGracePeriod:          10,
+		SoakHealthInterval:          60,
+		SoakErrorRateThreshold:      10.0,
+		SoakMaxBadIntervals:         3,
 	}
 }
 
@@ -78,7 +544,12 @@ func (c *Config) Save(filename string) error {
 	}
 	defer file.Close()
 
-	encoder := json.NewEncoder(file)
+	return c.WriteJSON(file)
+}
+
+// WriteJSON writes the config as indented JSON
+func (c *Config) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(c)
 }
@@ -0,0 +1,71 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/unicornultrafoundation/go-u2u/ethclient"
+)
+
+// poolMonitor wraps the HTTP transport of an optimized client and counts requests
+// in flight. Requests waiting for a free connection count too, so a peak at (or
+// above) max_connections means the pool, not the node, was limiting throughput.
+type poolMonitor struct {
+	next     http.RoundTripper
+	limit    int
+	inFlight int64  // atomic
+	peak     uint64 // atomic
+}
+
+func (m *poolMonitor) RoundTrip(req *http.Request) (*http.Response, error) {
+	storeMax(&m.peak, uint64(atomic.AddInt64(&m.inFlight, 1)))
+	defer atomic.AddInt64(&m.inFlight, -1)
+	return m.next.RoundTrip(req)
+}
+
+// Peak returns the highest number of concurrent requests since creation or the last Reset
+func (m *poolMonitor) Peak() uint64 {
+	return atomic.LoadUint64(&m.peak)
+}
+
+// Saturated reports whether the peak reached the connection cap
+func (m *poolMonitor) Saturated() bool {
+	return m.Peak() >= uint64(m.limit)
+}
+
+// Reset restarts peak tracking from the requests currently in flight (end of warmup)
+func (m *poolMonitor) Reset() {
+	current := atomic.LoadInt64(&m.inFlight)
+	if current < 0 {
+		current = 0
+	}
+	atomic.StoreUint64(&m.peak, uint64(current))
+}
+
+// Monitors of clients made by CreateOptimizedClient (*ethclient.Client → *poolMonitor)
+var poolMonitors sync.Map
+
+// poolMonitorFor returns the monitor of a client made by CreateOptimizedClient, or nil
+func poolMonitorFor(client *ethclient.Client) *poolMonitor {
+	if m, ok := poolMonitors.Load(client); ok {
+		return m.(*poolMonitor)
+	}
+	return nil
+}
+
+// printPoolReport prints peak concurrent requests against max_connections
+func (b *Benchmark) printPoolReport() {
+	if b.pool == nil {
+		return
+	}
+
+	peak := b.pool.Peak()
+	fmt.Printf("\n🔌 Connection Pool:\n")
+	fmt.Printf("  Peak In-Flight:     %d requests (max_connections %d, %.0f%%)\n",
+		peak, b.pool.limit, float64(peak)/float64(b.pool.limit)*100)
+	if b.pool.Saturated() {
+		fmt.Printf("  ⚠️  Pool saturated: requests queued for a connection; raise max_connections\n")
+	}
+}
@@ -0,0 +1,262 @@
+package internal
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+	"github.com/unicornultrafoundation/go-u2u/core/types"
+	"github.com/unicornultrafoundation/go-u2u/crypto"
+)
+
+// Argument placeholders for the "call" workload, filled in per send
+const (
+	argCounter   = "{counter}"   // Run-wide counter, incremented on every call
+	argSender    = "{sender}"    // Sending account
+	argRecipient = "{recipient}" // Account the transfer pattern picks
+)
+
+// callSpec is a parsed "call" workload: the target, selector and argument templates
+type callSpec struct {
+	to        common.Address
+	signature string // Empty when only a selector was given
+	selector  []byte
+	args      []callArg
+	value     *big.Int
+}
+
+// callArg is one static ABI argument: a pre-encoded word or a placeholder
+type callArg struct {
+	typ         string
+	word        []byte // Encoded literal (nil for placeholders)
+	placeholder string
+}
+
+// callSpec parses the call workload settings.
+// call_function is either an ABI signature ("transfer(address,uint256)") or a bare
+// 4-byte selector ("0xa9059cbb"); arguments need the signature form.
+func (c *Config) callSpec() (*callSpec, error) {
+	if !common.IsHexAddress(c.CallContractAddress) {
+		return nil, fmt.Errorf("call workload needs a valid call_contract_address, got %q", c.CallContractAddress)
+	}
+	spec := &callSpec{to: common.HexToAddress(c.CallContractAddress), value: new(big.Int)}
+
+	if c.CallValueWei != "" {
+		value, err := ParseAmount(c.CallValueWei, "wei")
+		if err != nil {
+			return nil, fmt.Errorf("call_value_wei: %v", err)
+		}
+		spec.value = value
+	}
+
+	function := strings.TrimSpace(c.CallFunction)
+	open := strings.Index(function, "(")
+	if open < 0 {
+		// Bare selector
+		selector := common.FromHex(function)
+		if len(selector) != 4 || !strings.HasPrefix(function, "0x") {
+			return nil, fmt.Errorf("call_function must be an ABI signature like \"increment(uint256)\" or a 4-byte selector like \"0xd09de08a\", got %q", c.CallFunction)
+		}
+		if len(c.CallArgs) > 0 {
+			return nil, fmt.Errorf("call_args need call_function as an ABI signature (types are unknown for a bare selector)")
+		}
+		spec.selector = selector
+		return spec, nil
+	}
+
+	if !strings.HasSuffix(function, ")") {
+		return nil, fmt.Errorf("invalid call_function %q", c.CallFunction)
+	}
+	var argTypes []string
+	if inner := function[open+1 : len(function)-1]; inner != "" {
+		argTypes = strings.Split(inner, ",")
+	}
+	if len(argTypes) != len(c.CallArgs) {
+		return nil, fmt.Errorf("call_function %q takes %d arguments, call_args has %d", c.CallFunction, len(argTypes), len(c.CallArgs))
+	}
+
+	for i, typ := range argTypes {
+		typ = strings.TrimSpace(typ)
+		argTypes[i] = typ
+		arg, err := parseCallArg(typ, strings.TrimSpace(c.CallArgs[i]))
+		if err != nil {
+			return nil, fmt.Errorf("call_args[%d]: %v", i, err)
+		}
+		spec.args = append(spec.args, arg)
+	}
+
+	// The selector hashes the canonical signature (no spaces)
+	spec.signature = function[:open] + "(" + strings.Join(argTypes, ",") + ")"
+	spec.selector = crypto.Keccak256([]byte(spec.signature))[:4]
+	return spec, nil
+}
+
+// parseCallArg validates one argument template against its ABI type
+func parseCallArg(typ, value string) (callArg, error) {
+	arg := callArg{typ: typ}
+	switch {
+	case value == argCounter:
+		if !strings.HasPrefix(typ, "uint") {
+			return arg, fmt.Errorf("%s needs a uint type, got %s", argCounter, typ)
+		}
+		arg.placeholder = value
+		return arg, nil
+	case value == argSender || value == argRecipient:
+		if typ != "address" {
+			return arg, fmt.Errorf("%s needs the address type, got %s", value, typ)
+		}
+		arg.placeholder = value
+		return arg, nil
+	}
+
+	word, err := encodeStaticArg(typ, value)
+	if err != nil {
+		return arg, err
+	}
+	arg.word = word
+	return arg, nil
+}
+
+// encodeStaticArg ABI-encodes a literal of a static type into one 32-byte word
+func encodeStaticArg(typ, value string) ([]byte, error) {
+	switch {
+	case typ == "address":
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("invalid address %q", value)
+		}
+		return common.LeftPadBytes(common.HexToAddress(value).Bytes(), 32), nil
+
+	case typ == "bool":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bool %q", value)
+		}
+		word := make([]byte, 32)
+		if b {
+			word[31] = 1
+		}
+		return word, nil
+
+	case strings.HasPrefix(typ, "uint") || strings.HasPrefix(typ, "int"):
+		bits, err := intTypeBits(typ)
+		if err != nil {
+			return nil, err
+		}
+		n, ok := new(big.Int).SetString(value, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s %q", typ, value)
+		}
+		if !intInRange(n, bits, strings.HasPrefix(typ, "int")) {
+			return nil, fmt.Errorf("%s out of range for %s", value, typ)
+		}
+		if n.Sign() < 0 {
+			// Two's complement over 256 bits
+			n.Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return common.LeftPadBytes(n.Bytes(), 32), nil
+
+	case strings.HasPrefix(typ, "bytes"):
+		size, err := strconv.Atoi(strings.TrimPrefix(typ, "bytes"))
+		if err != nil || size < 1 || size > 32 {
+			return nil, fmt.Errorf("unsupported type %s (only static types are supported)", typ)
+		}
+		b := common.FromHex(value)
+		if len(b) != size || !strings.HasPrefix(value, "0x") {
+			return nil, fmt.Errorf("%s needs %d bytes of 0x-prefixed hex, got %q", typ, size, value)
+		}
+		word := make([]byte, 32)
+		copy(word, b) // bytesN is left-aligned
+		return word, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s (only static types are supported)", typ)
+}
+
+// intTypeBits returns the width of a uintN/intN type (uint and int mean 256)
+func intTypeBits(typ string) (int, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int")
+	if digits == "" {
+		return 256, nil
+	}
+	bits, err := strconv.Atoi(digits)
+	if err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+		return 0, fmt.Errorf("unsupported type %s", typ)
+	}
+	return bits, nil
+}
+
+// intInRange reports whether n fits a uint<bits> or, when signed, an int<bits>
+// (two's complement: -2^(bits-1) through 2^(bits-1)-1)
+func intInRange(n *big.Int, bits int, signed bool) bool {
+	if !signed {
+		return n.Sign() >= 0 && n.BitLen() <= bits
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+	return n.Cmp(new(big.Int).Neg(limit)) >= 0 && n.Cmp(limit) < 0
+}
+
+// calldata builds the call's input with the placeholders filled in
+func (s *callSpec) calldata(counter uint64, sender, recipient common.Address) []byte {
+	data := make([]byte, 0, 4+32*len(s.args))
+	data = append(data, s.selector...)
+	for _, arg := range s.args {
+		switch arg.placeholder {
+		case argCounter:
+			data = append(data, common.LeftPadBytes(new(big.Int).SetUint64(counter).Bytes(), 32)...)
+		case argSender:
+			data = append(data, common.LeftPadBytes(sender.Bytes(), 32)...)
+		case argRecipient:
+			data = append(data, common.LeftPadBytes(recipient.Bytes(), 32)...)
+		default:
+			data = append(data, arg.word...)
+		}
+	}
+	return data
+}
+
+// usesRecipient reports whether any argument is the {recipient} placeholder
+func (s *callSpec) usesRecipient() bool {
+	for _, arg := range s.args {
+		if arg.placeholder == argRecipient {
+			return true
+		}
+	}
+	return false
+}
+
+// describe returns the function for the configuration banner
+func (s *callSpec) describe() string {
+	if s.signature != "" {
+		return s.signature
+	}
+	return "0x" + common.Bytes2Hex(s.selector)
+}
+
+// contractCallBuilder calls call_function on call_contract_address with templated arguments
+type contractCallBuilder struct {
+	b         *Benchmark
+	accountID int
+	rng       *rand.Rand
+	spec      *callSpec
+}
+
+func (c *contractCallBuilder) Build(account *AccountSender, nonce uint64) (*types.Transaction, error) {
+	counter := atomic.AddUint64(&c.b.callCounter, 1) - 1
+
+	var recipient common.Address
+	if c.spec.usesRecipient() {
+		recipient = c.b.accounts[c.b.recipientFor(c.accountID)].from
+	}
+
+	return c.b.gasFor(c.accountID).NewTx(
+		account.chainID,
+		nonce,
+		c.spec.to,
+		c.spec.value,
+		c.b.gasLimitFor(c.rng, intrinsicTransferGas),
+		c.spec.calldata(counter, account.from, recipient),
+	), nil
+}
@@ -0,0 +1,37 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+)
+
+// Thresholds for the final report's anomaly heuristics
+const (
+	diagMaxErrorRate      = 5.0  // percent
+	diagMaxTailLatencyX   = 10.0 // p99 vs median
+	diagMaxPeakToAverageX = 3.0  // peak TPS vs average TPS
+)
+
+// diagnose applies simple heuristics to the final metrics and returns
+// one human-readable line per anomaly found.
+func diagnose(minTPS, peakTPS uint64, avgTPS, errorRate float64, p50, p99 time.Duration) []string {
+	var flags []string
+
+	if minTPS == 0 {
+		flags = append(flags, "Stall: at least one interval had 0 TPS — the node or RPC stopped accepting transactions for a while")
+	}
+	if errorRate > diagMaxErrorRate {
+		flags = append(flags, fmt.Sprintf("High error rate: %.2f%% of sends failed (threshold %.0f%%) — check RPC limits and account balances",
+			errorRate, diagMaxErrorRate))
+	}
+	if p50 > 0 && float64(p99) > diagMaxTailLatencyX*float64(p50) {
+		flags = append(flags, fmt.Sprintf("Latency tail: p99 (%v) is more than %.0f× the median (%v) — some requests are queuing or timing out",
+			p99.Round(time.Millisecond), diagMaxTailLatencyX, p50.Round(time.Millisecond)))
+	}
+	if avgTPS > 0 && float64(peakTPS) > diagMaxPeakToAverageX*avgTPS {
+		flags = append(flags, fmt.Sprintf("Unstable throughput: peak TPS (%d) is more than %.0f× the average (%.2f) — load was bursty",
+			peakTPS, diagMaxPeakToAverageX, avgTPS))
+	}
+
+	return flags
+}
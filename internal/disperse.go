@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"math/big"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+)
+
+// Selector of disperseEther(address[],uint256[]) (the Disperse contract's native-coin entry point)
+var disperseEtherSelector = []byte{0xe6, 0x3d, 0x38, 0xed}
+
+// Default number of recipients per disperse call
+const DefaultDisperseBatchSize = 100
+
+// DisperseCalldata ABI-encodes a disperseEther call paying amounts[i] to recipients[i]
+func DisperseCalldata(recipients []common.Address, amounts []*big.Int) []byte {
+	word := func(v *big.Int) []byte {
+		return common.LeftPadBytes(v.Bytes(), 32)
+	}
+	n := int64(len(recipients))
+
+	data := make([]byte, 0, 4+32*(4+2*len(recipients)))
+	data = append(data, disperseEtherSelector...)
+
+	// Head: offsets of the two dynamic arrays
+	data = append(data, word(big.NewInt(64))...)
+	data = append(data, word(big.NewInt(64+32+32*n))...)
+
+	data = append(data, word(big.NewInt(n))...)
+	for _, recipient := range recipients {
+		data = append(data, common.LeftPadBytes(recipient.Bytes(), 32)...)
+	}
+
+	data = append(data, word(big.NewInt(int64(len(amounts))))...)
+	for _, amount := range amounts {
+		data = append(data, word(amount)...)
+	}
+	return data
+}
+
+// DisperseGasLimit is a conservative gas limit for a disperse call to n recipients:
+// each payment may create a new account (25000) on top of the value transfer (9000),
+// plus calldata and loop overhead. Unused gas is refunded.
+func DisperseGasLimit(n int) uint64 {
+	return 50000 + 40000*uint64(n)
+}
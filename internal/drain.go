@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// drainStats summarises the post-run mempool drain phase
+type drainStats struct {
+	submitted       uint64        // Everything submitted, including sends finishing after the window closed
+	confirmedAtEnd  uint64        // Confirmed when the send window closed
+	confirmedFinal  uint64        // Confirmed when the drain phase ended
+	duration        time.Duration // How long the drain phase ran
+	timedOut        bool          // Drain timeout hit with transactions still pending
+	pendingFinal    int           // Tracked transactions never confirmed
+	confirmedSeries []uint64      // Confirmed count after each second of draining
+}
+
+// inclusionRate returns the share of submitted transactions that made it on-chain
+func (d *drainStats) inclusionRate() float64 {
+	if d.submitted == 0 {
+		return 0
+	}
+	return float64(d.confirmedFinal) / float64(d.submitted) * 100
+}
+
+// runDrain keeps the receipt watcher running after submission has stopped until every
+// tracked transaction is confirmed or DrainTimeout elapses.
+func (b *Benchmark) runDrain() {
+	timeout := time.Duration(b.config.DrainTimeout) * time.Second
+	if timeout <= 0 {
+		return
+	}
+	if b.watcher == nil {
+		fmt.Printf("⚠️  drain_timeout_seconds needs track_confirmations, skipping drain phase\n")
+		return
+	}
+
+	stats := &drainStats{
+		submitted:      atomic.LoadUint64(&b.sentCount),
+		confirmedAtEnd: b.windowConfirmed,
+	}
+
+	fmt.Printf("\n🚰 Draining mempool for up to %v (no new submissions)...\n", timeout)
+
+	start := time.Now()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	deadline := time.After(timeout)
+
+	for b.watcher.Pending() > 0 {
+		select {
+		case <-deadline:
+			stats.timedOut = true
+		case <-ticker.C:
+			confirmed := b.watcher.Confirmed()
+			stats.confirmedSeries = append(stats.confirmedSeries, confirmed)
+			fmt.Printf("   %-8s confirmed %d / %d (%d pending)\n",
+				formatDuration(time.Since(start)), confirmed, stats.submitted, b.watcher.Pending())
+			continue
+		}
+		break
+	}
+
+	stats.duration = time.Since(start)
+	stats.confirmedFinal = b.watcher.Confirmed()
+	stats.pendingFinal = b.watcher.Pending()
+	b.drain = stats
+}
+
+// printDrainReport prints the drain section of the final report
+func (b *Benchmark) printDrainReport() {
+	d := b.drain
+	if d == nil {
+		return
+	}
+
+	fmt.Printf("\n🚰 Mempool Drain:\n")
+	fmt.Printf("  Drain Time:         %v", d.duration.Round(time.Millisecond))
+	if d.timedOut {
+		fmt.Printf(" (timed out, %d still pending)", d.pendingFinal)
+	}
+	fmt.Println()
+	fmt.Printf("  Confirmed at End:   %d\n", d.confirmedAtEnd)
+	fmt.Printf("  Confirmed in Drain: %d\n", d.confirmedFinal-d.confirmedAtEnd)
+	fmt.Printf("  Final Inclusion:    %d of %d submitted (%.2f%%)\n", d.confirmedFinal, d.submitted, d.inclusionRate())
+}
@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/unicornultrafoundation/go-u2u/ethclient"
+)
+
+// With rpc_urls, the run spreads accounts over several RPC endpoints. Each account is pinned
+// to one endpoint for the whole run (sticky routing): its sends, nonce reads and resyncs all
+// go to the same node, so it never sees a pending nonce from a mempool that lacks its own txs.
+
+// Endpoints returns rpc_url followed by the extra rpc_urls, without duplicates
+func (c *Config) Endpoints() []string {
+	endpoints := []string{c.RPCURL}
+	seen := map[string]bool{c.RPCURL: true}
+	for _, url := range c.RPCURLs {
+		url = strings.TrimSpace(url)
+		if url != "" && !seen[url] {
+			endpoints = append(endpoints, url)
+			seen[url] = true
+		}
+	}
+	return endpoints
+}
+
+// PinEndpoints assigns account i to endpoint i mod len(Endpoints()), switches it to that
+// endpoint's client and re-reads its pending nonce there. It returns a function closing the
+// extra clients. Without rpc_urls it does nothing.
+func PinEndpoints(ctx context.Context, config *Config, accounts []*AccountSender, tlsConfig *tls.Config) (func(), error) {
+	endpoints := config.Endpoints()
+	if len(endpoints) < 2 || len(accounts) == 0 {
+		return func() {}, nil
+	}
+
+	// The accounts already use the rpc_url client; connect to the others
+	var extra []*ethclient.Client
+	closeAll := func() {
+		for _, client := range extra {
+			client.Close()
+		}
+	}
+	clients := make([]*ethclient.Client, len(endpoints))
+	clients[0] = accounts[0].client
+	for e := 1; e < len(endpoints); e++ {
+		client, err := CreateOptimizedClient(endpoints[e], config.GetMaxConnections(), tlsConfig, config.RetryPolicy())
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("failed to connect to %s: %v", endpoints[e], err)
+		}
+		clients[e] = client
+		extra = append(extra, client)
+	}
+
+	pinned := make([]int, len(endpoints))
+	for i, account := range accounts {
+		account.endpoint = i % len(endpoints)
+		account.client = clients[account.endpoint]
+		pinned[account.endpoint]++
+
+		// Continue from the pinned endpoint's view of the pending nonce (a nonce_offset or
+		// no_preflight start nonce is kept as is)
+		if config.NonceOffset != 0 || config.NoPreflight {
+			continue
+		}
+		if err := account.ResyncNonce(ctx); err != nil {
+			closeAll()
+			return nil, fmt.Errorf("failed to get nonce for account %d from %s: %v", i, endpoints[account.endpoint], err)
+		}
+	}
+
+	fmt.Printf("🔀 Pinned %d accounts to %d RPC endpoints:\n", len(accounts), len(endpoints))
+	for e, url := range endpoints {
+		fmt.Printf("  [%d] %s: %d accounts\n", e, url, pinned[e])
+	}
+	return closeAll, nil
+}
+
+// EndpointStats is the share of one RPC endpoint in a pinned run
+type EndpointStats struct {
+	URL      string `json:"url"`
+	Accounts []int  `json:"accounts"` // Indexes of the accounts pinned to it
+	Sent     uint64 `json:"sent"`
+	Errors   uint64 `json:"errors"`
+}
+
+// endpointStats sums the accounts' counters per endpoint (nil without rpc_urls)
+func (b *Benchmark) endpointStats() []EndpointStats {
+	endpoints := b.config.Endpoints()
+	if len(endpoints) < 2 {
+		return nil
+	}
+	stats := make([]EndpointStats, len(endpoints))
+	for e, url := range endpoints {
+		stats[e] = EndpointStats{URL: url, Accounts: []int{}}
+	}
+	for i, account := range b.accounts {
+		s := &stats[account.endpoint]
+		s.Accounts = append(s.Accounts, i)
+		s.Sent += atomic.LoadUint64(&account.sent)
+		s.Errors += atomic.LoadUint64(&account.errors)
+	}
+	return stats
+}
+
+// printEndpointReport shows the account assignment and outcome of every pinned endpoint
+func (b *Benchmark) printEndpointReport() {
+	stats := b.endpointStats()
+	if stats == nil {
+		return
+	}
+	fmt.Printf("\n🔀 RPC Endpoints (accounts pinned):\n")
+	for e, s := range stats {
+		errorRate := 0.0
+		if s.Sent+s.Errors > 0 {
+			errorRate = float64(s.Errors) / float64(s.Sent+s.Errors) * 100
+		}
+		fmt.Printf("  [%d] %s: %d accounts, %d sent, %d errors (%.1f%%)\n", e, s.URL, len(s.Accounts), s.Sent, s.Errors, errorRate)
+	}
+}
@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// Error types used in the per-interval breakdown
+const (
+	ErrorKindNonce       = "nonce"
+	ErrorKindTimeout     = "timeout"
+	ErrorKindRateLimit   = "rate_limit"
+	ErrorKindFunds       = "funds"
+	ErrorKindMempoolFull = "mempool_full"
+	ErrorKindOther       = "other"
+)
+
+// errorKinds lists the error types in report order
+var errorKinds = [...]string{ErrorKindNonce, ErrorKindTimeout, ErrorKindRateLimit, ErrorKindFunds, ErrorKindMempoolFull, ErrorKindOther}
+
+// classifyError maps a send error to one of the error types.
+// Nonce and rate-limit errors use the same checks as the send loop.
+func classifyError(err error) string {
+	switch {
+	case isNonceError(err):
+		return ErrorKindNonce
+	case isRateLimitError(err):
+		return ErrorKindRateLimit
+	}
+
+	errStr := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(errStr, "insufficient funds"):
+		return ErrorKindFunds
+	case strings.Contains(errStr, "txpool is full"), strings.Contains(errStr, "pool is full"),
+		strings.Contains(errStr, "mempool is full"):
+		return ErrorKindMempoolFull
+	case strings.Contains(errStr, "timeout"), strings.Contains(errStr, "deadline exceeded"):
+		return ErrorKindTimeout
+	default:
+		return ErrorKindOther
+	}
+}
+
+// errorKindCounter counts rejected send attempts (retries included) by error type
+type errorKindCounter struct {
+	counts [len(errorKinds)]uint64 // atomic, indexed like errorKinds
+}
+
+// Record classifies and counts one rejected attempt
+func (c *errorKindCounter) Record(err error) {
+	kind := classifyError(err)
+	for i, k := range errorKinds {
+		if k == kind {
+			atomic.AddUint64(&c.counts[i], 1)
+			return
+		}
+	}
+}
+
+// Snapshot returns the current counts, indexed like errorKinds
+func (c *errorKindCounter) Snapshot() [len(errorKinds)]uint64 {
+	var snapshot [len(errorKinds)]uint64
+	for i := range c.counts {
+		snapshot[i] = atomic.LoadUint64(&c.counts[i])
+	}
+	return snapshot
+}
+
+// Reset zeroes all counts (end of warmup)
+func (c *errorKindCounter) Reset() {
+	for i := range c.counts {
+		atomic.StoreUint64(&c.counts[i], 0)
+	}
+}
+
+// errorKindMap turns counts (or deltas) indexed like errorKinds into a map for JSON
+func errorKindMap(counts [len(errorKinds)]uint64) map[string]uint64 {
+	m := make(map[string]uint64, len(errorKinds))
+	for i, kind := range errorKinds {
+		m[kind] = counts[i]
+	}
+	return m
+}
@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// errorSampler keeps the first few distinct send error messages with their counts.
+// Logging every error is infeasible at high error volumes; a bounded set of
+// representative messages is enough to see what went wrong.
+type errorSampler struct {
+	mu        sync.Mutex
+	limit     int
+	counts    map[string]uint64
+	order     []string // Distinct messages in first-seen order
+	unsampled uint64   // Errors whose message arrived after the set was full
+}
+
+// ErrorSample is one distinct error message and how often it occurred
+type ErrorSample struct {
+	Message string `json:"message"`
+	Count   uint64 `json:"count"`
+}
+
+func newErrorSampler(limit int) *errorSampler {
+	return &errorSampler{limit: limit, counts: make(map[string]uint64)}
+}
+
+// Record counts err, keeping its message if it is known or there is room for a new one
+func (s *errorSampler) Record(err error) {
+	msg := err.Error()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.counts[msg]; ok {
+		s.counts[msg]++
+		return
+	}
+	if len(s.order) >= s.limit {
+		s.unsampled++
+		return
+	}
+	s.counts[msg] = 1
+	s.order = append(s.order, msg)
+}
+
+// Samples returns the sampled messages, most frequent first, and the count of other errors
+func (s *errorSampler) Samples() ([]ErrorSample, uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	samples := make([]ErrorSample, 0, len(s.order))
+	for _, msg := range s.order {
+		samples = append(samples, ErrorSample{Message: msg, Count: s.counts[msg]})
+	}
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].Count > samples[j].Count
+	})
+	return samples, s.unsampled
+}
+
+// Reset forgets all samples (end of warmup)
+func (s *errorSampler) Reset() {
+	s.mu.Lock()
+	s.counts = make(map[string]uint64)
+	s.order = nil
+	s.unsampled = 0
+	s.mu.Unlock()
+}
+
+// printErrorSamples prints the sampled error messages in the final report
+func (b *Benchmark) printErrorSamples() {
+	samples, others := b.errorSamples.Samples()
+	if len(samples) == 0 {
+		return
+	}
+
+	fmt.Printf("\n🧾 Error Samples (first %d distinct messages):\n", b.errorSamples.limit)
+	for _, sample := range samples {
+		fmt.Printf("  %8d × %s\n", sample.Count, sample.Message)
+	}
+	if others > 0 {
+		fmt.Printf("  %8d × (other messages, not sampled)\n", others)
+	}
+}
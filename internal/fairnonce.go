@@ -0,0 +1,58 @@
+package internal
+
+import "sync"
+
+// nonceTurnstile dispatches an account's transactions in nonce order (fair_nonce).
+//
+// With several workers per account, nonces are handed out atomically but the
+// sends race: the worker holding nonce n+3 can reach the node before the one
+// holding n, which then queues the later nonces as future transactions. The
+// turnstile is a ticket lock keyed by nonce: workers still sign in parallel,
+// but each one waits for every lower nonce to be dispatched before it submits.
+// Only the start of a send is ordered, so requests stay pipelined.
+type nonceTurnstile struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	next uint64 // Lowest nonce not yet dispatched
+}
+
+func newNonceTurnstile(next uint64) *nonceTurnstile {
+	t := &nonceTurnstile{next: next}
+	t.cond = sync.NewCond(&t.mu)
+	return t
+}
+
+// await blocks until every nonce below nonce has been dispatched, then marks nonce dispatched
+func (t *nonceTurnstile) await(nonce uint64) {
+	t.mu.Lock()
+	for t.next < nonce {
+		t.cond.Wait()
+	}
+	if nonce >= t.next {
+		t.next = nonce + 1
+	}
+	t.mu.Unlock()
+	t.cond.Broadcast()
+}
+
+// reset restarts the sequence at nonce (after a resync), releasing workers waiting on skipped nonces
+func (t *nonceTurnstile) reset(nonce uint64) {
+	t.mu.Lock()
+	t.next = nonce
+	t.mu.Unlock()
+	t.cond.Broadcast()
+}
+
+// EnableFairNonce makes concurrent workers on this account submit in nonce order
+func (a *AccountSender) EnableFairNonce() {
+	a.turns = newNonceTurnstile(a.CurrentNonce())
+}
+
+// awaitTurn waits for this nonce's dispatch turn (no-op unless fair_nonce is enabled).
+// Every nonce drawn with GetNextNonce must pass through here, even if signing failed,
+// or the workers holding later nonces would wait forever.
+func (a *AccountSender) awaitTurn(nonce uint64) {
+	if a.turns != nil {
+		a.turns.await(nonce)
+	}
+}
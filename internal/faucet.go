@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+	"github.com/unicornultrafoundation/go-u2u/ethclient"
+)
+
+// Faucet request limits: each address gets up to faucetAttempts requests, backing off
+// from faucetBackoffBase up to faucetBackoffMax while the faucet is rate limiting
+const (
+	faucetAttempts    = 6
+	faucetBackoffBase = 2 * time.Second
+	faucetBackoffMax  = 60 * time.Second
+	faucetPollEvery   = 2 * time.Second
+)
+
+// faucetStatusError is a non-2xx faucet response
+type faucetStatusError struct {
+	status     int
+	body       string
+	retryAfter time.Duration // From the Retry-After header (0 if absent)
+}
+
+func (e *faucetStatusError) Error() string {
+	if e.body == "" {
+		return fmt.Sprintf("faucet returned HTTP %d", e.status)
+	}
+	return fmt.Sprintf("faucet returned HTTP %d: %s", e.status, e.body)
+}
+
+// retryable reports whether the faucet asked us to slow down or is briefly unavailable
+func (e *faucetStatusError) retryable() bool {
+	return e.status == http.StatusTooManyRequests || e.status == http.StatusServiceUnavailable
+}
+
+// FaucetTopUp requests funds from faucet_url for every account below minBalance,
+// then waits up to faucet_timeout_seconds for the balances to go up.
+// Accounts the faucet could not fund are left as they are; CheckBalances decides what happens to them.
+func FaucetTopUp(ctx context.Context, config *Config, client *ethclient.Client, accounts []*AccountSender, minBalance *big.Int) error {
+	requested := make(map[common.Address]*big.Int)
+	for i, account := range accounts {
+		balance, err := client.BalanceAt(ctx, account.from, nil)
+		if err != nil {
+			return fmt.Errorf("failed to check balance for account %d: %v", i, err)
+		}
+		if balance.Cmp(minBalance) >= 0 {
+			continue
+		}
+
+		if err := requestFaucet(ctx, config, account.from); err != nil {
+			fmt.Printf("⚠️  Faucet request for account %d (%s) failed: %v\n", i, account.from.Hex(), err)
+			continue
+		}
+		fmt.Printf("🚰 Requested faucet funds for account %d (%s)\n", i, account.from.Hex())
+		requested[account.from] = balance
+	}
+	if len(requested) == 0 {
+		return nil
+	}
+
+	timeout := config.GetFaucetTimeout()
+	fmt.Printf("⏳ Waiting up to %v for %d faucet payouts...\n", timeout, len(requested))
+	deadline := time.Now().Add(timeout)
+	for len(requested) > 0 && time.Now().Before(deadline) {
+		select {
+		case <-time.After(faucetPollEvery):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		for addr, before := range requested {
+			balance, err := client.BalanceAt(ctx, addr, nil)
+			if err != nil || balance.Cmp(before) <= 0 {
+				continue
+			}
+			fmt.Printf("✅ %s received %s U2U\n", addr.Hex(), FormatU2U(new(big.Int).Sub(balance, before)))
+			delete(requested, addr)
+		}
+	}
+	if len(requested) > 0 {
+		fmt.Printf("⚠️  %d faucet payouts did not arrive within %v\n", len(requested), timeout)
+	}
+	return nil
+}
+
+// requestFaucet POSTs one address to the faucet, backing off while it is rate limited
+func requestFaucet(ctx context.Context, config *Config, addr common.Address) error {
+	body := strings.ReplaceAll(config.GetFaucetRequestTemplate(), "{address}", addr.Hex())
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	backoff := faucetBackoffBase
+	for attempt := 1; ; attempt++ {
+		err := postFaucet(ctx, client, config.FaucetURL, config.GetFaucetContentType(), body)
+		if err == nil {
+			return nil
+		}
+		statusErr, ok := err.(*faucetStatusError)
+		if !ok || !statusErr.retryable() || attempt >= faucetAttempts {
+			return err
+		}
+
+		wait := backoff
+		if statusErr.retryAfter > faucetBackoffMax {
+			return fmt.Errorf("%v (asked to retry in %v)", err, statusErr.retryAfter)
+		} else if statusErr.retryAfter > 0 {
+			wait = statusErr.retryAfter
+		}
+		fmt.Printf("⚠️  Faucet is rate limiting (attempt %d/%d); retrying in %v\n", attempt, faucetAttempts, wait)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if backoff *= 2; backoff > faucetBackoffMax {
+			backoff = faucetBackoffMax
+		}
+	}
+}
+
+// postFaucet sends a single faucet request
+func postFaucet(ctx context.Context, client *http.Client, url, contentType, body string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBufferString(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+	statusErr := &faucetStatusError{status: resp.StatusCode, body: strings.TrimSpace(string(msg))}
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		statusErr.retryAfter = time.Duration(secs) * time.Second
+	}
+	return statusErr
+}
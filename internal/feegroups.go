@@ -0,0 +1,141 @@
+package internal
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Fee groups split the accounts into contiguous groups (like key shards) that
+// each price their transactions at a multiple of the resolved gas price, so
+// fee-based prioritization shows up as different inclusion rates and latencies.
+
+// validateFeeGroups checks gas_price_multipliers against the account count
+func validateFeeGroups(config *Config, numAccounts int) error {
+	multipliers := config.GasPriceMultipliers
+	if len(multipliers) == 0 {
+		return nil
+	}
+	if len(multipliers) > numAccounts {
+		return fmt.Errorf("gas_price_multipliers has %d groups but only %d accounts", len(multipliers), numAccounts)
+	}
+	for i, m := range multipliers {
+		if m <= 0 {
+			return fmt.Errorf("gas_price_multipliers[%d] must be greater than zero, got %g", i, m)
+		}
+	}
+	return nil
+}
+
+// feeGroupGas returns one GasSettings per fee group and each account's group index
+func feeGroupGas(config *Config, base *GasSettings, numAccounts int) ([]*GasSettings, []int) {
+	multipliers := config.GasPriceMultipliers
+	if len(multipliers) == 0 {
+		return []*GasSettings{base}, make([]int, numAccounts)
+	}
+
+	groups := make([]*GasSettings, len(multipliers))
+	accountGroup := make([]int, numAccounts)
+	for g, m := range multipliers {
+		groups[g] = base.Scaled(m)
+		start, end := ShardRange(numAccounts, len(multipliers), g)
+		for i := start; i < end; i++ {
+			accountGroup[i] = g
+		}
+	}
+	return groups, accountGroup
+}
+
+// gasFor returns the pricing for an account's transactions
+func (b *Benchmark) gasFor(accountID int) *GasSettings {
+	return b.feeGroups[b.accountGroup[accountID]]
+}
+
+// feeGroupStats aggregates one fee group's results
+type feeGroupStats struct {
+	multiplier   float64
+	firstAccount int
+	lastAccount  int
+	sent         uint64
+	avgLatency   time.Duration
+	confirmed    uint64
+	inclusion    float64
+	p50Confirm   time.Duration
+}
+
+// feeGroupStats collects per-group sent/latency from the accounts and confirmations from the watcher
+func (b *Benchmark) feeGroupStats() []feeGroupStats {
+	multipliers := b.config.GasPriceMultipliers
+	stats := make([]feeGroupStats, len(multipliers))
+	for g, m := range multipliers {
+		start, end := ShardRange(len(b.accounts), len(multipliers), g)
+		s := &stats[g]
+		s.multiplier = m
+		s.firstAccount, s.lastAccount = start, end-1
+
+		var totalLatency int64
+		for _, account := range b.accounts[start:end] {
+			s.sent += atomic.LoadUint64(&account.sent)
+			totalLatency += atomic.LoadInt64(&account.latency)
+		}
+		if s.sent > 0 {
+			s.avgLatency = time.Duration(totalLatency / int64(s.sent))
+		}
+
+		if b.watcher != nil {
+			s.confirmed = atomic.LoadUint64(&b.watcher.groupConfirmed[g])
+			s.p50Confirm = b.watcher.groupLatencies[g].Percentile(50)
+			if s.sent > 0 {
+				s.inclusion = float64(s.confirmed) / float64(s.sent) * 100
+			}
+		}
+	}
+	return stats
+}
+
+// printFeeGroupReport prints the fee group section of the final report
+func (b *Benchmark) printFeeGroupReport() {
+	if len(b.config.GasPriceMultipliers) == 0 {
+		return
+	}
+
+	fmt.Printf("\n💲 Fee Groups:\n")
+	for g, s := range b.feeGroupStats() {
+		fmt.Printf("  Group %d (%.2f×, accounts %d-%d, %s):\n", g, s.multiplier, s.firstAccount, s.lastAccount, b.feeGroups[g].String())
+		fmt.Printf("    Sent: %d, avg send latency %v\n", s.sent, b.roundLatency(s.avgLatency))
+		if b.watcher != nil {
+			fmt.Printf("    Confirmed: %d (%.2f%% inclusion), median confirmation %v\n",
+				s.confirmed, s.inclusion, b.roundLatency(s.p50Confirm))
+		}
+	}
+	if b.watcher == nil {
+		fmt.Printf("  (enable track_confirmations for per-group inclusion rates)\n")
+	}
+}
+
+// feeGroupResults returns the fee group section of the JSON results
+func (b *Benchmark) feeGroupResults() []map[string]interface{} {
+	if len(b.config.GasPriceMultipliers) == 0 {
+		return nil
+	}
+
+	var results []map[string]interface{}
+	for g, s := range b.feeGroupStats() {
+		group := map[string]interface{}{
+			"group":          g,
+			"multiplier":     s.multiplier,
+			"first_account":  s.firstAccount,
+			"last_account":   s.lastAccount,
+			"gas_pricing":    b.feeGroups[g].String(),
+			"sent":           s.sent,
+			"avg_latency_ms": s.avgLatency.Milliseconds(),
+		}
+		if b.watcher != nil {
+			group["confirmed"] = s.confirmed
+			group["inclusion_rate"] = s.inclusion
+			group["p50_confirmation_ms"] = s.p50Confirm.Milliseconds()
+		}
+		results = append(results, group)
+	}
+	return results
+}
@@ -0,0 +1,150 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/unicornultrafoundation/go-u2u/rpc"
+)
+
+// finalityTracker measures time from submission until a transaction's block is finalized.
+// The receipt watcher hands over every confirmed block with its transactions' submission
+// times; each poll asks the node for its "finalized" block and settles everything at or
+// below it. Nodes without the finalized tag are detected up front and finality is skipped.
+type finalityTracker struct {
+	rpc *rpc.Client
+
+	mu       sync.Mutex
+	awaiting []finalityBlock // Confirmed, not yet finalized, oldest first
+
+	finalized uint64 // atomic
+	latencies latencyHistogram
+}
+
+// finalityBlock is a confirmed block waiting for finality
+type finalityBlock struct {
+	number    uint64
+	submitted []time.Time
+}
+
+// newFinalityTracker connects to the node and checks that it supports the finalized tag
+func newFinalityTracker(ctx context.Context, config *Config) (*finalityTracker, error) {
+	client, err := config.dialRPC(ctx, config.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %v", err)
+	}
+
+	f := &finalityTracker{rpc: client}
+	if _, err := f.finalizedHead(ctx); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// finalizedHead returns the number of the node's latest finalized block
+func (f *finalityTracker) finalizedHead(ctx context.Context) (uint64, error) {
+	var head *struct {
+		Number string `json:"number"`
+	}
+	if err := f.rpc.CallContext(ctx, &head, "eth_getBlockByNumber", "finalized", false); err != nil {
+		return 0, fmt.Errorf("node does not support the finalized block tag: %v", err)
+	}
+	if head == nil {
+		return 0, fmt.Errorf("node returned no finalized block")
+	}
+
+	number, err := strconv.ParseUint(strings.TrimPrefix(head.Number, "0x"), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid finalized block number %q: %v", head.Number, err)
+	}
+	return number, nil
+}
+
+// add queues a confirmed block's transactions until the block is finalized
+func (f *finalityTracker) add(number uint64, submitted []time.Time) {
+	f.mu.Lock()
+	f.awaiting = append(f.awaiting, finalityBlock{number: number, submitted: submitted})
+	f.mu.Unlock()
+}
+
+// poll records the finality latency of every queued block at or below the finalized head
+func (f *finalityTracker) poll(ctx context.Context) error {
+	head, err := f.finalizedHead(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	settled := 0
+	for _, block := range f.awaiting {
+		if block.number > head {
+			break
+		}
+		for _, submitted := range block.submitted {
+			f.latencies.Record(now.Sub(submitted))
+		}
+		atomic.AddUint64(&f.finalized, uint64(len(block.submitted)))
+		settled++
+	}
+	f.awaiting = f.awaiting[settled:]
+	return nil
+}
+
+// Finalized returns how many tracked transactions reached a finalized block
+func (f *finalityTracker) Finalized() uint64 {
+	return atomic.LoadUint64(&f.finalized)
+}
+
+// Reset forgets queued blocks and zeroes the counters (end of warmup)
+func (f *finalityTracker) Reset() {
+	f.mu.Lock()
+	f.awaiting = nil
+	f.mu.Unlock()
+	atomic.StoreUint64(&f.finalized, 0)
+	f.latencies.Reset()
+}
+
+// Close releases the tracker's RPC connection
+func (f *finalityTracker) Close() {
+	f.rpc.Close()
+}
+
+// printFinalityReport prints finality latency percentiles
+func (b *Benchmark) printFinalityReport() {
+	if b.watcher == nil || b.watcher.finality == nil {
+		return
+	}
+
+	f := b.watcher.finality
+	fmt.Printf("\n🏁 Finality:\n")
+	fmt.Printf("  Finalized:          %d of %d confirmed transactions\n", f.Finalized(), b.watcher.Confirmed())
+	if f.Finalized() == 0 {
+		return
+	}
+	fmt.Printf("  P50 Finality:       %v\n", b.roundLatency(f.latencies.Percentile(50)))
+	fmt.Printf("  P95 Finality:       %v\n", b.roundLatency(f.latencies.Percentile(95)))
+	fmt.Printf("  P99 Finality:       %v\n", b.roundLatency(f.latencies.Percentile(99)))
+}
+
+// finalityResults fills the finality fields of the results
+func (b *Benchmark) finalityResults(results *Results) {
+	if b.watcher == nil || b.watcher.finality == nil {
+		return
+	}
+
+	f := b.watcher.finality
+	results.TotalFinalized = f.Finalized()
+	if results.TotalFinalized > 0 {
+		results.P50FinalityMs = f.latencies.Percentile(50).Milliseconds()
+		results.P95FinalityMs = f.latencies.Percentile(95).Milliseconds()
+		results.P99FinalityMs = f.latencies.Percentile(99).Milliseconds()
+	}
+}
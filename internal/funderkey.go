@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/unicornultrafoundation/go-u2u/accounts/keystore"
+	"github.com/unicornultrafoundation/go-u2u/crypto"
+)
+
+// The funding account's key comes from FUNDER_PRIVATE_KEY, or from a file so it stays out of
+// process listings and shell history. The file holds either the hex key (an optional 0x prefix
+// and # comment lines are allowed) or an encrypted keystore entry, as written by `u2u account new`.
+
+// LoadFunderKey returns the funder key from keyFile, or from FUNDER_PRIVATE_KEY when keyFile is
+// empty. A keystore entry is decrypted with the contents of passwordFile, or with
+// FUNDER_KEY_PASSWORD when passwordFile is empty.
+func LoadFunderKey(keyFile, passwordFile string) (*ecdsa.PrivateKey, error) {
+	if keyFile == "" {
+		keyHex := os.Getenv("FUNDER_PRIVATE_KEY")
+		if keyHex == "" {
+			return nil, fmt.Errorf("FUNDER_PRIVATE_KEY environment variable is not set (or use -funder-key-file)")
+		}
+		key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(keyHex), "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid FUNDER_PRIVATE_KEY: %v", err)
+		}
+		return key, nil
+	}
+
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read funder key file: %v", err)
+	}
+	warnReadableKeyFile(keyFile)
+
+	// Keystore entry: a JSON object with a "crypto" section
+	var entry struct {
+		Crypto json.RawMessage `json:"crypto"`
+	}
+	if json.Unmarshal(data, &entry) == nil && entry.Crypto != nil {
+		password, err := funderKeyPassword(passwordFile)
+		if err != nil {
+			return nil, err
+		}
+		key, err := keystore.DecryptKey(data, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt keystore %s: %v", keyFile, err)
+		}
+		return key.PrivateKey, nil
+	}
+
+	keys := parseKeyLines(string(data))
+	if len(keys) != 1 {
+		return nil, fmt.Errorf("funder key file %s must hold exactly one hex key or a keystore entry (found %d lines)", keyFile, len(keys))
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(keys[0], "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid key in %s: %v", keyFile, err)
+	}
+	return key, nil
+}
+
+// funderKeyPassword reads the keystore passphrase (a single trailing newline is dropped)
+func funderKeyPassword(passwordFile string) (string, error) {
+	if passwordFile == "" {
+		password, ok := os.LookupEnv("FUNDER_KEY_PASSWORD")
+		if !ok {
+			return "", fmt.Errorf("funder key file is a keystore entry; set -funder-key-password-file or FUNDER_KEY_PASSWORD")
+		}
+		return password, nil
+	}
+	data, err := os.ReadFile(passwordFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read funder key password file: %v", err)
+	}
+	password := strings.TrimSuffix(string(data), "\n")
+	return strings.TrimSuffix(password, "\r"), nil
+}
+
+// warnReadableKeyFile warns when other users can read a key file
+func warnReadableKeyFile(filename string) {
+	info, err := os.Stat(filename)
+	if err == nil && info.Mode().Perm()&0o077 != 0 {
+		fmt.Printf("⚠️  %s is readable by other users (mode %04o); consider chmod 600\n", filename, info.Mode().Perm())
+	}
+}
@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// FundingAmounts returns what cmd/fund pays each of n accounts. Every account gets base times
+// its weight from weights, a comma-separated list of index:weight or from-to:weight entries
+// (e.g. "0-1:50,2:10"; unlisted accounts weigh 1, weight 0 skips the account). Rows of
+// amountFile, a CSV of accountIndex,amount (bare amounts in U2U), then set exact amounts.
+func FundingAmounts(n int, base *big.Int, weights, amountFile string) ([]*big.Int, error) {
+	amounts := make([]*big.Int, n)
+	for i := range amounts {
+		amounts[i] = new(big.Int).Set(base)
+	}
+
+	if weights != "" {
+		for _, entry := range strings.Split(weights, ",") {
+			from, to, weight, err := parseWeight(strings.TrimSpace(entry), n)
+			if err != nil {
+				return nil, fmt.Errorf("invalid weight %q: %v", entry, err)
+			}
+			for i := from; i <= to; i++ {
+				scaled := new(big.Rat).Mul(new(big.Rat).SetInt(base), weight)
+				amounts[i] = new(big.Int).Quo(scaled.Num(), scaled.Denom())
+			}
+		}
+	}
+
+	if amountFile != "" {
+		file, err := os.Open(amountFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open amount file: %v", err)
+		}
+		defer file.Close()
+		if err := readAmountFile(file, amounts); err != nil {
+			return nil, fmt.Errorf("%s: %v", amountFile, err)
+		}
+	}
+	return amounts, nil
+}
+
+// parseWeight parses one "index:weight" or "from-to:weight" entry
+func parseWeight(entry string, n int) (int, int, *big.Rat, error) {
+	accounts, value, ok := strings.Cut(entry, ":")
+	if !ok {
+		return 0, 0, nil, fmt.Errorf("expected index:weight or from-to:weight")
+	}
+	if !decimalNumber.MatchString(strings.TrimSpace(value)) {
+		return 0, 0, nil, fmt.Errorf("%q is not a decimal number", value)
+	}
+	weight, _ := new(big.Rat).SetString(strings.TrimSpace(value))
+	if weight.Sign() < 0 {
+		return 0, 0, nil, fmt.Errorf("weight must not be negative")
+	}
+
+	fromText, toText, isRange := strings.Cut(accounts, "-")
+	from, err := strconv.Atoi(strings.TrimSpace(fromText))
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid account index %q", fromText)
+	}
+	to := from
+	if isRange {
+		if to, err = strconv.Atoi(strings.TrimSpace(toText)); err != nil {
+			return 0, 0, nil, fmt.Errorf("invalid account index %q", toText)
+		}
+	}
+	if from < 0 || to < from {
+		return 0, 0, nil, fmt.Errorf("invalid account range %d-%d", from, to)
+	}
+	if to >= n {
+		return 0, 0, nil, fmt.Errorf("account %d is out of range (%d accounts to fund)", to, n)
+	}
+	return from, to, weight, nil
+}
+
+// Column checks of an amount file row, for telling a header from a malformed first row
+var amountColumns = []func(string) bool{
+	isInteger,
+	func(s string) bool { _, err := ParseAmount(s, "u2u"); return err == nil },
+}
+
+// readAmountFile applies the accountIndex,amount rows of a CSV to amounts.
+// A header row and lines starting with # are skipped.
+func readAmountFile(r io.Reader, amounts []*big.Int) error {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := reader.FieldPos(0)
+
+		if first && isHeaderRow(record, amountColumns) {
+			continue
+		}
+		index, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil {
+			return fmt.Errorf("line %d: invalid account index %q", line, record[0])
+		}
+		if index < 0 || index >= len(amounts) {
+			return fmt.Errorf("line %d: account %d is out of range (%d accounts to fund)", line, index, len(amounts))
+		}
+		amount, err := ParseAmount(strings.TrimSpace(record[1]), "u2u")
+		if err != nil {
+			return fmt.Errorf("line %d: %v", line, err)
+		}
+		amounts[index] = amount
+	}
+}
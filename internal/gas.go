@@ -0,0 +1,215 @@
+package internal
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+	"github.com/unicornultrafoundation/go-u2u/core/types"
+	"github.com/unicornultrafoundation/go-u2u/ethclient"
+)
+
+// Tip strategies for dynamic-fee transactions
+const (
+	TipSuggested  = "suggested"  // eth_maxPriorityFeePerGas
+	TipFixed      = "fixed"      // A configured tip
+	TipPercentile = "percentile" // A reward percentile of recent blocks (eth_feeHistory)
+)
+
+// Blocks inspected by the percentile tip strategy
+const tipHistoryBlocks = 20
+
+// TipSettings selects how the priority fee is chosen in dynamic-fee mode
+type TipSettings struct {
+	Strategy   string  // TipSuggested (default), TipFixed or TipPercentile
+	FixedTip   string  // Tip for TipFixed, in wei or with a unit ("2 gwei")
+	Percentile float64 // Reward percentile for TipPercentile (default 50)
+}
+
+// GasSettings describes how transactions are priced
+type GasSettings struct {
+	DynamicFee bool     // Build EIP-1559 (type 2) transactions instead of legacy ones
+	GasPrice   *big.Int // Legacy gas price, or the fee cap for dynamic-fee transactions
+	GasTipCap  *big.Int // Priority fee (dynamic-fee only)
+	TipSource  string   // Where the tip came from, for reports (dynamic-fee only)
+}
+
+// ResolveGasSettings prices transactions from a fixed value or the node's suggestion.
+// The fixed value is in wei unless it carries a unit ("5 gwei").
+// For dynamic fees the fee cap defaults to 2 × base fee + tip, like geth.
+func ResolveGasSettings(ctx context.Context, client *ethclient.Client, fixedGasPrice string, dynamicFee bool, tip TipSettings) (*GasSettings, error) {
+	var fixedPrice *big.Int
+	if fixedGasPrice != "" {
+		price, err := ParseAmount(fixedGasPrice, "wei")
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas price: %v", err)
+		}
+		if price.Sign() == 0 {
+			return nil, fmt.Errorf("invalid gas price: %q must be greater than zero", fixedGasPrice)
+		}
+		fixedPrice = price
+	}
+
+	if !dynamicFee {
+		if fixedPrice != nil {
+			return &GasSettings{GasPrice: fixedPrice}, nil
+		}
+		gasPrice, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get gas price: %v", err)
+		}
+		return &GasSettings{GasPrice: gasPrice}, nil
+	}
+
+	tipCap, tipSource, err := resolveTip(ctx, client, tip)
+	if err != nil {
+		return nil, err
+	}
+
+	feeCap := fixedPrice
+	if feeCap == nil {
+		head, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest header: %v", err)
+		}
+		if head.BaseFee == nil {
+			return nil, fmt.Errorf("node does not report a base fee (EIP-1559 not supported)")
+		}
+		feeCap = new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), tipCap)
+	}
+
+	// The tip can never exceed the fee cap
+	if tipCap.Cmp(feeCap) > 0 {
+		tipCap = new(big.Int).Set(feeCap)
+	}
+
+	return &GasSettings{
+		DynamicFee: true,
+		GasPrice:   feeCap,
+		GasTipCap:  tipCap,
+		TipSource:  tipSource,
+	}, nil
+}
+
+// resolveTip picks the priority fee according to the tip strategy
+func resolveTip(ctx context.Context, client *ethclient.Client, tip TipSettings) (*big.Int, string, error) {
+	switch tip.Strategy {
+	case "", TipSuggested:
+		tipCap, err := client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get gas tip cap: %v", err)
+		}
+		return tipCap, "suggested", nil
+
+	case TipFixed:
+		if tip.FixedTip == "" {
+			return nil, "", fmt.Errorf("tip strategy %q needs a fixed tip", TipFixed)
+		}
+		tipCap, err := ParseAmount(tip.FixedTip, "wei")
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid tip: %v", err)
+		}
+		return tipCap, "fixed", nil
+
+	case TipPercentile:
+		percentile := tip.Percentile
+		if percentile <= 0 {
+			percentile = 50
+		}
+		if percentile > 100 {
+			return nil, "", fmt.Errorf("invalid tip percentile %.1f: must be between 0 and 100", percentile)
+		}
+		return percentileTip(ctx, client, percentile)
+
+	default:
+		return nil, "", fmt.Errorf("unknown tip strategy %q (use %q, %q or %q)", tip.Strategy, TipSuggested, TipFixed, TipPercentile)
+	}
+}
+
+// percentileTip returns the median, across recent blocks, of each block's reward at the
+// given percentile. Falls back to the node's suggestion when recent blocks carry no rewards.
+func percentileTip(ctx context.Context, client *ethclient.Client, percentile float64) (*big.Int, string, error) {
+	history, err := client.FeeHistory(ctx, tipHistoryBlocks, nil, []float64{percentile})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get fee history: %v", err)
+	}
+
+	var rewards []*big.Int
+	for _, blockRewards := range history.Reward {
+		if len(blockRewards) > 0 && blockRewards[0] != nil {
+			rewards = append(rewards, blockRewards[0])
+		}
+	}
+	if len(rewards) == 0 {
+		fmt.Printf("⚠️  No priority fees in the last %d blocks, using the node's suggested tip\n", tipHistoryBlocks)
+		return resolveTip(ctx, client, TipSettings{Strategy: TipSuggested})
+	}
+
+	sort.Slice(rewards, func(i, j int) bool { return rewards[i].Cmp(rewards[j]) < 0 })
+	source := fmt.Sprintf("p%g of last %d blocks", percentile, len(rewards))
+	return new(big.Int).Set(rewards[len(rewards)/2]), source, nil
+}
+
+// Scaled returns a copy with the gas price (and tip) multiplied by multiplier
+func (g *GasSettings) Scaled(multiplier float64) *GasSettings {
+	scaled := *g
+	scaled.GasPrice = scaleWei(g.GasPrice, multiplier)
+	if g.GasTipCap != nil {
+		scaled.GasTipCap = scaleWei(g.GasTipCap, multiplier)
+	}
+	return &scaled
+}
+
+func scaleWei(wei *big.Int, multiplier float64) *big.Int {
+	scaled, _ := new(big.Float).Mul(new(big.Float).SetInt(wei), big.NewFloat(multiplier)).Int(nil)
+	return scaled
+}
+
+// NewTx builds an unsigned legacy or dynamic-fee transaction
+func (g *GasSettings) NewTx(chainID *big.Int, nonce uint64, to common.Address, value *big.Int, gasLimit uint64, data []byte) *types.Transaction {
+	if g.DynamicFee {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: g.GasTipCap,
+			GasFeeCap: g.GasPrice,
+			Gas:       gasLimit,
+			To:        &to,
+			Value:     value,
+			Data:      data,
+		})
+	}
+	return types.NewTransaction(nonce, to, value, gasLimit, g.GasPrice, data)
+}
+
+// NewDeployTx builds an unsigned contract creation with this pricing
+func (g *GasSettings) NewDeployTx(chainID *big.Int, nonce uint64, value *big.Int, gasLimit uint64, code []byte) *types.Transaction {
+	if g.DynamicFee {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: g.GasTipCap,
+			GasFeeCap: g.GasPrice,
+			Gas:       gasLimit,
+			Value:     value,
+			Data:      code,
+		})
+	}
+	return types.NewContractCreation(nonce, value, gasLimit, g.GasPrice, code)
+}
+
+// String describes the pricing for banners
+func (g *GasSettings) String() string {
+	if g.DynamicFee {
+		return fmt.Sprintf("EIP-1559 (fee cap %s wei, tip %s wei, %s)", g.GasPrice.String(), g.GasTipCap.String(), g.TipSource)
+	}
+	return fmt.Sprintf("%s wei", g.GasPrice.String())
+}
+
+// SignTransaction signs a transaction of any supported type for the given chain
+func SignTransaction(tx *types.Transaction, chainID *big.Int, key *ecdsa.PrivateKey) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+}
@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Intrinsic gas of a plain value transfer; no transaction the benchmark sends can use less
+const intrinsicTransferGas = 21000
+
+// Gas of an ERC-20 transfer() to an address that holds no tokens yet (a fresh storage slot).
+// Transfers to existing holders need ~35000, so limits below this revert on some recipients.
+const typicalERC20TransferGas = 50000
+
+// Gas charged per byte of runtime code stored by a deployment
+const codeDepositGasPerByte = 200
+
+// MaxGasLimit returns the largest gas limit a transaction can get with gas_limit_jitter_percent applied
+func (c *Config) MaxGasLimit() uint64 {
+	if c.GasLimitJitterPercent <= 0 {
+		return c.GasLimit
+	}
+	return c.GasLimit + c.GasLimit*uint64(c.GasLimitJitterPercent)/100
+}
+
+// MinGasLimit returns the smallest gas limit a transaction can get with gas_limit_jitter_percent applied
+func (c *Config) MinGasLimit() uint64 {
+	if c.GasLimitJitterPercent <= 0 {
+		return c.GasLimit
+	}
+	delta := c.GasLimit * uint64(c.GasLimitJitterPercent) / 100
+	if delta >= c.GasLimit || c.GasLimit-delta < intrinsicTransferGas {
+		return intrinsicTransferGas
+	}
+	return c.GasLimit - delta
+}
+
+// checkGasLimit cross-checks gas_limit against what the workload's transactions need.
+// Limits that cannot work at all are errors; limits that will revert some transactions
+// (e.g. ERC-20 transfers to new holders) return a warning for the banner.
+func checkGasLimit(config *Config) (warning string, err error) {
+	if len(config.WorkloadMix) > 0 {
+		return checkMixGasLimit(config)
+	}
+	low := config.MinGasLimit()
+	limit := fmt.Sprintf("gas_limit %d", config.GasLimit)
+	if low != config.GasLimit {
+		limit += fmt.Sprintf(" (down to %d with jitter)", low)
+	}
+
+	switch config.Workload {
+	case WorkloadRead:
+		return "", nil
+	case WorkloadERC20:
+		if low <= intrinsicTransferGas {
+			return "", fmt.Errorf("erc20 workload needs gas_limit above %d, got %s (token transfers typically use ~%d; try 65000)",
+				intrinsicTransferGas, limit, typicalERC20TransferGas)
+		}
+		if low < typicalERC20TransferGas {
+			return fmt.Sprintf("%s is below the ~%d an ERC-20 transfer to a new holder needs; expect reverts", limit, typicalERC20TransferGas), nil
+		}
+	case WorkloadDeploy:
+		code, err := config.deployCode()
+		if err != nil {
+			return "", err
+		}
+		floor := intrinsicCreateGas(code)
+		if low < floor {
+			return "", fmt.Errorf("deploy workload needs gas_limit of at least %d plus the init code's execution cost, got %s", floor, limit)
+		}
+		if deposit := floor + codeDepositGasPerByte*uint64(len(code)); low < deposit {
+			return fmt.Sprintf("%s leaves less than %d gas/byte for storing the deployed code (%d with all %d bytes stored); deployments may run out of gas",
+				limit, codeDepositGasPerByte, deposit, len(code)), nil
+		}
+	case WorkloadCall:
+		if low <= intrinsicTransferGas {
+			return "", fmt.Errorf("call workload needs gas_limit above %d to cover the contract's execution, got %s", intrinsicTransferGas, limit)
+		}
+	default:
+		if config.GasLimit < intrinsicTransferGas {
+			return "", fmt.Errorf("transfers need gas_limit of at least %d, got %d", intrinsicTransferGas, config.GasLimit)
+		}
+	}
+	return "", nil
+}
+
+// gasLimitFor returns the gas limit for the next transaction: gas_limit perturbed by up to
+// ±gas_limit_jitter_percent using the worker's own RNG, never below the builder's intrinsic floor.
+func (b *Benchmark) gasLimitFor(rng *rand.Rand, floor uint64) uint64 {
+	base := b.config.GasLimit
+	if b.config.GasLimitJitterPercent <= 0 {
+		return base
+	}
+
+	delta := int64(base * uint64(b.config.GasLimitJitterPercent) / 100)
+	limit := int64(base) - delta + rng.Int63n(2*delta+1)
+	if limit < int64(floor) {
+		limit = int64(floor)
+	}
+	return uint64(limit)
+}
@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+	"github.com/unicornultrafoundation/go-u2u/crypto"
+)
+
+// genesisAccount is one prefunded account of a genesis allocation.
+// geth-style allocs carry only a balance; test genesis files may also hold the key.
+type genesisAccount struct {
+	Address    string `json:"address"` // Only in the prefunded-accounts list form
+	Balance    string `json:"balance"`
+	SecretKey  string `json:"secretKey"`
+	PrivateKey string `json:"private_key"`
+}
+
+// key returns the embedded private key in hex, if any
+func (a genesisAccount) key() string {
+	if a.PrivateKey != "" {
+		return a.PrivateKey
+	}
+	return a.SecretKey
+}
+
+// parseGenesisAlloc reads the prefunded accounts of a genesis file. Accepted forms:
+// a geth-style genesis with an "alloc" section, a bare alloc map (address → account),
+// or a list of {"address", "private_key", "balance"} objects.
+func parseGenesisAlloc(data []byte) (map[common.Address]genesisAccount, error) {
+	var genesis struct {
+		Alloc map[string]genesisAccount `json:"alloc"`
+	}
+	var alloc map[string]genesisAccount
+	if err := json.Unmarshal(data, &genesis); err == nil && len(genesis.Alloc) > 0 {
+		alloc = genesis.Alloc
+	} else if err := json.Unmarshal(data, &alloc); err != nil || len(alloc) == 0 {
+		var list []genesisAccount
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, fmt.Errorf("expected a genesis with \"alloc\", an alloc map or a list of prefunded accounts")
+		}
+		alloc = make(map[string]genesisAccount, len(list))
+		for _, account := range list {
+			alloc[account.Address] = account
+		}
+	}
+
+	accounts := make(map[common.Address]genesisAccount, len(alloc))
+	for addr, account := range alloc {
+		if !strings.HasPrefix(addr, "0x") && !strings.HasPrefix(addr, "0X") {
+			addr = "0x" + addr
+		}
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid address in alloc: %q", addr)
+		}
+		accounts[common.HexToAddress(addr)] = account
+	}
+	return accounts, nil
+}
+
+// LoadGenesisKeys returns the keys of the accounts prefunded in a genesis file: keys embedded
+// in the alloc first (sorted by address), then keys from keysFile whose address is allocated.
+// keysFile is optional when the genesis embeds keys. Keys without an allocation are left out,
+// so no separate funding step is needed.
+func LoadGenesisKeys(genesisFile, keysFile string) ([]*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(genesisFile)
+	if err != nil {
+		return nil, err
+	}
+	alloc, err := parseGenesisAlloc(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", genesisFile, err)
+	}
+
+	addresses := make([]common.Address, 0, len(alloc))
+	for addr := range alloc {
+		addresses = append(addresses, addr)
+	}
+	sort.Slice(addresses, func(i, j int) bool { return addresses[i].Hex() < addresses[j].Hex() })
+
+	var keys []*ecdsa.PrivateKey
+	seen := make(map[common.Address]bool)
+	for _, addr := range addresses {
+		keyHex := alloc[addr].key()
+		if keyHex == "" {
+			continue
+		}
+		keyBytes, err := hex.DecodeString(strings.TrimPrefix(keyHex, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode key of %s: %v", addr.Hex(), err)
+		}
+		key, err := crypto.ToECDSA(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key of %s: %v", addr.Hex(), err)
+		}
+		if crypto.PubkeyToAddress(key.PublicKey) != addr {
+			return nil, fmt.Errorf("key given for %s belongs to %s", addr.Hex(), crypto.PubkeyToAddress(key.PublicKey).Hex())
+		}
+		keys = append(keys, key)
+		seen[addr] = true
+	}
+	embedded := len(keys)
+
+	unallocated := 0
+	if _, err := os.Stat(keysFile); err == nil || embedded == 0 {
+		fileKeys, err := LoadPrivateKeys(keysFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range fileKeys {
+			addr := crypto.PubkeyToAddress(key.PublicKey)
+			if _, ok := alloc[addr]; !ok {
+				unallocated++
+				continue
+			}
+			if !seen[addr] {
+				keys = append(keys, key)
+				seen[addr] = true
+			}
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("none of the %d accounts allocated in %s has a known key", len(alloc), genesisFile)
+	}
+
+	total := new(big.Int)
+	for _, key := range keys {
+		if balance, ok := new(big.Int).SetString(alloc[crypto.PubkeyToAddress(key.PublicKey)].Balance, 0); ok {
+			total.Add(total, balance)
+		}
+	}
+	fmt.Printf("✅ Genesis alloc: %d prefunded accounts with keys (%d embedded), %s U2U allocated in total\n",
+		len(keys), embedded, FormatU2U(total))
+	if unallocated > 0 {
+		fmt.Printf("⚠️  Skipped %d keys from %s without a genesis allocation\n", unallocated, keysFile)
+	}
+	return keys, nil
+}
@@ -0,0 +1,59 @@
+package internal
+
+import "fmt"
+
+// Headline metrics: which TPS number is the primary result of a run
+const (
+	HeadlineSubmitted = "submitted" // Transactions accepted by the RPC endpoint (default)
+	HeadlineConfirmed = "confirmed" // Transactions seen in a block (needs track_confirmations)
+)
+
+// GetHeadlineMetric returns the headline metric (default "submitted")
+func (c *Config) GetHeadlineMetric() string {
+	if c.HeadlineMetric == "" {
+		return HeadlineSubmitted
+	}
+	return c.HeadlineMetric
+}
+
+// validateHeadline checks headline_metric and min_tps
+func validateHeadline(config *Config) error {
+	switch config.GetHeadlineMetric() {
+	case HeadlineSubmitted:
+	case HeadlineConfirmed:
+		if !config.TrackConfirmations {
+			return fmt.Errorf("headline_metric %q needs track_confirmations", HeadlineConfirmed)
+		}
+	default:
+		return fmt.Errorf("unknown headline_metric %q (use %q or %q)", config.HeadlineMetric, HeadlineSubmitted, HeadlineConfirmed)
+	}
+	if config.MinTPS < 0 {
+		return fmt.Errorf("min_tps must not be negative, got %g", config.MinTPS)
+	}
+	return nil
+}
+
+// headlineTPS picks the configured headline out of the average submitted and confirmed TPS
+func (c *Config) headlineTPS(submitted, confirmed float64) float64 {
+	if c.GetHeadlineMetric() == HeadlineConfirmed {
+		return confirmed
+	}
+	return submitted
+}
+
+// MinTPSFailed reports whether the run missed the min_tps gate
+func (r *Results) MinTPSFailed() bool {
+	return r.MinTPS > 0 && r.HeadlineTPS < r.MinTPS
+}
+
+// printHeadline shows the primary result and the min_tps gate at the top of the report
+func (b *Benchmark) printHeadline(tps float64) {
+	fmt.Printf("\n🏁 Headline:           %.2f %s TPS\n", tps, b.config.GetHeadlineMetric())
+	if b.config.MinTPS > 0 {
+		status := "✅ passed"
+		if tps < b.config.MinTPS {
+			status = "❌ failed"
+		}
+		fmt.Printf("  Min TPS Gate:       %g %s\n", b.config.MinTPS, status)
+	}
+}
@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	tpsHistogramBuckets  = 10
+	tpsHistogramBarWidth = 40
+)
+
+// tpsHistogramLines renders the distribution of per-interval TPS as ASCII bars,
+// one line per TPS range. Returns nil when there is nothing to show.
+func tpsHistogramLines(tpsHistory []uint64) []string {
+	if len(tpsHistory) == 0 {
+		return nil
+	}
+
+	low, high, _ := calculateTPSStats(tpsHistory)
+
+	// Bucket width rounded up so the top bucket includes the peak
+	buckets := uint64(tpsHistogramBuckets)
+	span := high - low + 1
+	if span < buckets {
+		buckets = span
+	}
+	width := (span + buckets - 1) / buckets
+	buckets = (span + width - 1) / width
+
+	counts := make([]int, buckets)
+	maxCount := 0
+	for _, tps := range tpsHistory {
+		i := (tps - low) / width
+		counts[i]++
+		if counts[i] > maxCount {
+			maxCount = counts[i]
+		}
+	}
+
+	lines := make([]string, 0, buckets)
+	for i, count := range counts {
+		from := low + uint64(i)*width
+		to := from + width - 1
+		bar := strings.Repeat("█", count*tpsHistogramBarWidth/maxCount)
+		if count > 0 && bar == "" {
+			bar = "▏"
+		}
+		lines = append(lines, fmt.Sprintf("%7d - %-7d | %-*s %d", from, to, tpsHistogramBarWidth, bar, count))
+	}
+	return lines
+}
@@ -0,0 +1,229 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Measurement of every exported InfluxDB point
+const influxMeasurement = "u2u_benchmark"
+
+// InfluxDB line protocol output: one point per report interval (tag type=interval) and one
+// with the final aggregates (type=final), tagged with the run label and chain ID. Points go
+// to influx_file as they are produced; influx_url receives all of them in one write at the end.
+
+// influxExporter collects and writes line-protocol points
+type influxExporter struct {
+	mu     sync.Mutex
+	file   *os.File // nil without influx_file
+	url    string
+	token  string
+	tags   map[string]string
+	lines  []string // Kept for the push to influx_url
+	failed bool     // Set after the first file write error; later lines are not written
+}
+
+// newInfluxExporter opens influx_file (if set); tags are added to every point
+func newInfluxExporter(config *Config, tags map[string]string) (*influxExporter, error) {
+	e := &influxExporter{url: config.InfluxURL, token: config.InfluxToken, tags: tags}
+	if config.InfluxFile != "" {
+		file, err := os.Create(config.InfluxFile)
+		if err != nil {
+			return nil, err
+		}
+		e.file = file
+	}
+	return e, nil
+}
+
+// influxPoint is one line before encoding
+type influxPoint struct {
+	tags   map[string]string
+	fields map[string]interface{} // float64, int64 or uint64
+	at     time.Time
+}
+
+// line encodes the point in line protocol: measurement,tags fields timestamp(ns)
+func (p influxPoint) line() string {
+	var buf strings.Builder
+	buf.WriteString(influxMeasurement)
+	for _, k := range sortedKeys(p.tags) {
+		if p.tags[k] == "" {
+			continue // Empty tag values are not allowed
+		}
+		fmt.Fprintf(&buf, ",%s=%s", influxEscape(k), influxEscape(p.tags[k]))
+	}
+	for i, k := range sortedKeys(p.fields) {
+		sep := ","
+		if i == 0 {
+			sep = " "
+		}
+		var value string
+		switch v := p.fields[k].(type) {
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		case int64:
+			value = strconv.FormatInt(v, 10) + "i"
+		case uint64:
+			value = strconv.FormatUint(v, 10) + "i"
+		}
+		fmt.Fprintf(&buf, "%s%s=%s", sep, influxEscape(k), value)
+	}
+	fmt.Fprintf(&buf, " %d", p.at.UnixNano())
+	return buf.String()
+}
+
+// influxEscape escapes commas, spaces and equals signs in keys and tag values
+func influxEscape(s string) string {
+	return strings.NewReplacer(",", `\,`, " ", `\ `, "=", `\=`).Replace(s)
+}
+
+// sortedKeys returns the keys of a map in order, so points are stable between runs
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// add records a point with the exporter's tags plus type, writing it to influx_file.
+// Only the first write error is returned.
+func (e *influxExporter) add(pointType string, fields map[string]interface{}, at time.Time) error {
+	tags := map[string]string{"type": pointType}
+	for k, v := range e.tags {
+		tags[k] = v
+	}
+	line := influxPoint{tags: tags, fields: fields, at: at}.line()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.lines = append(e.lines, line)
+	if e.file == nil || e.failed {
+		return nil
+	}
+	if _, err := e.file.WriteString(line + "\n"); err != nil {
+		e.failed = true
+		return err
+	}
+	return nil
+}
+
+// WriteInterval adds the point of one report interval
+func (e *influxExporter) WriteInterval(record StreamRecord, at time.Time) error {
+	fields := map[string]interface{}{
+		"elapsed_seconds": record.ElapsedSeconds,
+		"tps":             record.Submitted,
+		"submitted_total": record.TotalSubmitted,
+		"errors_total":    record.TotalErrors,
+		"latency_avg_ms":  record.AvgLatencyMs,
+	}
+	if record.ScheduledTPS != nil {
+		fields["scheduled_tps"] = *record.ScheduledTPS
+	}
+	if record.Confirmed != nil {
+		fields["confirmed_total"] = *record.Confirmed
+		fields["backlog"] = *record.Backlog
+	}
+	return e.add("interval", fields, at)
+}
+
+// WriteFinal adds the point with the final aggregates
+func (e *influxExporter) WriteFinal(r *Results, at time.Time) error {
+	fields := map[string]interface{}{
+		"submitted_total": r.TotalSubmitted,
+		"errors_total":    r.TotalErrors,
+		"retries_total":   r.TotalRetries,
+		"nonce_errors":    r.NonceErrors,
+		"nonce_error_pct": r.NonceErrorPercent,
+		"rpc_accept_rate": r.RPCAcceptRate,
+		"tps_avg":         r.AvgSubmittedTPS,
+		"tps_peak":        r.PeakSubmittedTPS,
+		"tps_median":      r.MedianSubmittedTPS,
+		"latency_avg_ms":  r.AvgLatencyMs,
+		"latency_p50_ms":  r.P50LatencyMs,
+		"latency_p95_ms":  r.P95LatencyMs,
+		"latency_p99_ms":  r.P99LatencyMs,
+	}
+	if duration, ok := r.Config["duration_seconds"].(float64); ok {
+		fields["duration_seconds"] = duration
+	}
+	if r.TotalConfirmed > 0 {
+		fields["confirmed_total"] = r.TotalConfirmed
+		fields["confirmed_tps_avg"] = r.AvgConfirmedTPS
+	}
+	if r.BlocksProduced > 0 {
+		fields["blocks_produced"] = r.BlocksProduced
+	}
+	return e.add("final", fields, at)
+}
+
+// Close closes influx_file
+func (e *influxExporter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.file == nil {
+		return nil
+	}
+	return e.file.Close()
+}
+
+// Push writes every collected point to influx_url in one request. The URL is the full write
+// endpoint, e.g. http://influx:8086/api/v2/write?org=o&bucket=b (v2) or http://influx:8086/write?db=d (v1).
+func (e *influxExporter) Push() error {
+	e.mu.Lock()
+	body := strings.Join(e.lines, "\n") + "\n"
+	e.mu.Unlock()
+
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewBufferString(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if e.token != "" {
+		req.Header.Set("Authorization", "Token "+e.token)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("InfluxDB returned %s", resp.Status)
+	}
+	return nil
+}
+
+// exportInflux writes the final point and pushes all points when influx_url is set.
+// Failures are reported but do not fail the run; the JSON results are already on disk.
+func (b *Benchmark) exportInflux() {
+	if b.influx == nil || b.results == nil {
+		return
+	}
+	if err := b.influx.WriteFinal(b.results, b.endTime); err != nil {
+		fmt.Printf("⚠️  Failed to write InfluxDB points: %v\n", err)
+	}
+	if err := b.influx.Close(); err != nil {
+		fmt.Printf("⚠️  Failed to write InfluxDB points: %v\n", err)
+	} else if b.config.InfluxFile != "" {
+		fmt.Printf("📝 InfluxDB line protocol saved to %s\n", b.config.InfluxFile)
+	}
+
+	if b.config.InfluxURL != "" {
+		if err := b.influx.Push(); err != nil {
+			fmt.Printf("⚠️  Failed to push points to InfluxDB: %v\n", err)
+		} else {
+			fmt.Printf("📤 %d points written to InfluxDB\n", len(b.influx.lines))
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/unicornultrafoundation/go-u2u/ethclient"
+)
+
+// Headers sampled to estimate the block time
+const profileBlockSpan = 20
+
+// Headroom over the node's suggested gas price in a generated config
+const profileGasPriceHeadroom = 1.2
+
+// ChainProfile is what cmd/init detects about an RPC endpoint
+type ChainProfile struct {
+	RPCURL         string
+	ChainID        int64
+	LatestBlock    uint64
+	EIP1559        bool
+	BaseFee        *big.Int // nil without EIP-1559
+	SuggestedPrice *big.Int // eth_gasPrice
+	SuggestedTip   *big.Int // nil without EIP-1559 or when the node has no tip suggestion
+	BlockTime      time.Duration
+	BatchErr       error // nil when JSON-RPC batches work
+}
+
+// ProfileChain reads the chain ID, fee model, gas price and block time of the endpoint
+// tlsConfig is used for the batch probe's own connection (nil = default verification).
+func ProfileChain(ctx context.Context, client *ethclient.Client, rpcURL string, tlsConfig *tls.Config) (*ChainProfile, error) {
+	p := &ChainProfile{RPCURL: rpcURL}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %v", err)
+	}
+	p.ChainID = chainID.Int64()
+
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %v", err)
+	}
+	p.LatestBlock = head.Number.Uint64()
+	if head.BaseFee != nil {
+		p.EIP1559 = true
+		p.BaseFee = head.BaseFee
+		if tip, err := client.SuggestGasTipCap(ctx); err == nil {
+			p.SuggestedTip = tip
+		}
+	}
+
+	p.SuggestedPrice, err = client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %v", err)
+	}
+
+	// Average block time over the last blocks (unknown on a chain this young)
+	if span := min(uint64(profileBlockSpan), p.LatestBlock); span > 0 {
+		old, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(p.LatestBlock-span))
+		if err == nil && head.Time > old.Time {
+			p.BlockTime = time.Duration(head.Time-old.Time) * time.Second / time.Duration(span)
+		}
+	}
+
+	p.BatchErr = probeBatchSupport(ctx, rpcURL, tlsConfig)
+	return p, nil
+}
+
+// Config returns the defaults adjusted to the chain: its chain ID, transaction type, a gas
+// price with headroom over the node's suggestion and a block scan interval matching the block time
+func (p *ChainProfile) Config() *Config {
+	config := DefaultConfig()
+	config.RPCURL = p.RPCURL
+	config.ChainID = p.ChainID
+
+	price := scaleWei(p.SuggestedPrice, profileGasPriceHeadroom)
+	if p.EIP1559 {
+		config.EIP1559 = true
+		config.TipStrategy = "suggested"
+		// Fee cap as the benchmark derives it at runtime: twice the base fee plus the tip
+		tip := p.SuggestedTip
+		if tip == nil {
+			tip = new(big.Int)
+		}
+		feeCap := new(big.Int).Add(new(big.Int).Mul(p.BaseFee, big.NewInt(2)), tip)
+		if feeCap.Cmp(price) > 0 {
+			price = feeCap
+		}
+	}
+	config.FixedGasPriceWei = formatGasPrice(price)
+
+	if p.BlockTime > 0 {
+		poll := min(max(p.BlockTime/2, 100*time.Millisecond), time.Second)
+		config.ConfirmationPollMs = int(poll.Milliseconds())
+	}
+	return config
+}
+
+// formatGasPrice writes a gas price in whole gwei when it is at least 1 gwei (rounded up), else in wei
+func formatGasPrice(wei *big.Int) string {
+	gwei := big.NewInt(1e9)
+	if wei.Cmp(gwei) < 0 {
+		return wei.String()
+	}
+	whole, rest := new(big.Int).QuoRem(wei, gwei, new(big.Int))
+	if rest.Sign() > 0 {
+		whole.Add(whole, big.NewInt(1))
+	}
+	return whole.String() + " gwei"
+}
+
+// Print shows what was detected
+func (p *ChainProfile) Print() {
+	fmt.Printf("\n🔎 Detected:\n")
+	fmt.Printf("  %-22s%d\n", "Chain ID:", p.ChainID)
+	fmt.Printf("  %-22s%d\n", "Latest Block:", p.LatestBlock)
+	if p.EIP1559 {
+		fmt.Printf("  %-22ssupported (base fee %s U2U)\n", "EIP-1559:", FormatU2U(p.BaseFee))
+	} else {
+		fmt.Printf("  %-22snot supported (legacy transactions)\n", "EIP-1559:")
+	}
+	fmt.Printf("  %-22s%s wei\n", "Suggested Gas Price:", p.SuggestedPrice)
+	if p.SuggestedTip != nil {
+		fmt.Printf("  %-22s%s wei\n", "Suggested Tip:", p.SuggestedTip)
+	}
+	if p.BlockTime > 0 {
+		fmt.Printf("  %-22s%v (last %d blocks)\n", "Block Time:", p.BlockTime, min(uint64(profileBlockSpan), p.LatestBlock))
+	} else {
+		fmt.Printf("  %-22sunknown\n", "Block Time:")
+	}
+	if p.BatchErr != nil {
+		fmt.Printf("  %-22snot supported (%v)\n", "JSON-RPC Batches:", p.BatchErr)
+	} else {
+		fmt.Printf("  %-22ssupported\n", "JSON-RPC Batches:")
+	}
+}
@@ -0,0 +1,160 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+)
+
+const (
+	latencyBucketsPerE = 20  // ~5% bucket width
+	latencyBuckets     = 400 // covers 1µs .. ~8 minutes
+)
+
+// latencyHistogram is a lock-free log-scale histogram of send latencies.
+// Percentiles are accurate to within one bucket (~5%).
+type latencyHistogram struct {
+	buckets [latencyBuckets]uint64
+}
+
+func latencyBucket(d time.Duration) int {
+	us := d.Microseconds()
+	if us < 1 {
+		us = 1
+	}
+	i := int(math.Log(float64(us)) * latencyBucketsPerE)
+	if i >= latencyBuckets {
+		i = latencyBuckets - 1
+	}
+	return i
+}
+
+// Record adds one latency sample (thread-safe)
+func (h *latencyHistogram) Record(d time.Duration) {
+	atomic.AddUint64(&h.buckets[latencyBucket(d)], 1)
+}
+
+// Reset clears all samples
+func (h *latencyHistogram) Reset() {
+	for i := range h.buckets {
+		atomic.StoreUint64(&h.buckets[i], 0)
+	}
+}
+
+// Percentile returns the upper bound of the bucket holding the p-th percentile (0-100)
+func (h *latencyHistogram) Percentile(p float64) time.Duration {
+	var counts [latencyBuckets]uint64
+	total := uint64(0)
+	for i := range h.buckets {
+		counts[i] = atomic.LoadUint64(&h.buckets[i])
+		total += counts[i]
+	}
+	if total == 0 {
+		return 0
+	}
+
+	rank := uint64(math.Ceil(p / 100 * float64(total)))
+	if rank == 0 {
+		rank = 1
+	}
+
+	seen := uint64(0)
+	for i, c := range counts {
+		seen += c
+		if seen >= rank {
+			upperUs := math.Exp(float64(i+1) / latencyBucketsPerE)
+			return time.Duration(upperUs * float64(time.Microsecond))
+		}
+	}
+	return 0
+}
+
+// roundLatency rounds a latency for display to the configured latency_precision
+func (b *Benchmark) roundLatency(d time.Duration) time.Duration {
+	return d.Round(b.latencyUnit)
+}
+
+// TxSample identifies a single transaction with its latency (fastest/slowest reports)
+type TxSample struct {
+	LatencyMs float64 `json:"latency_ms"`
+	TxHash    string  `json:"tx_hash,omitempty"` // Empty for reads
+	AccountID int     `json:"account_id"`        // -1 when unknown
+	latency   time.Duration
+}
+
+// String formats the sample for the final report
+func (s *TxSample) String() string {
+	text := s.latency.Round(time.Microsecond).String()
+	if s.TxHash != "" {
+		text += " (" + s.TxHash
+		if s.AccountID >= 0 {
+			text += fmt.Sprintf(", account %d", s.AccountID)
+		}
+		text += ")"
+	} else if s.AccountID >= 0 {
+		text += fmt.Sprintf(" (account %d)", s.AccountID)
+	}
+	return text
+}
+
+// latencyExtremes remembers the fastest and slowest individual transactions.
+// The common case (not a new extreme) only does two atomic loads.
+type latencyExtremes struct {
+	min int64 // atomic nanoseconds, 0 = no sample yet
+	max int64 // atomic nanoseconds
+
+	mu      sync.Mutex
+	fastest *TxSample
+	slowest *TxSample
+}
+
+// Record offers one sample (thread-safe)
+func (e *latencyExtremes) Record(d time.Duration, hash common.Hash, accountID int) {
+	ns := int64(d)
+	if ns <= 0 {
+		ns = 1
+	}
+	min := atomic.LoadInt64(&e.min)
+	if min != 0 && ns >= min && ns <= atomic.LoadInt64(&e.max) {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	sample := &TxSample{
+		LatencyMs: float64(d) / float64(time.Millisecond),
+		AccountID: accountID,
+		latency:   d,
+	}
+	if hash != (common.Hash{}) {
+		sample.TxHash = hash.Hex()
+	}
+	if e.fastest == nil || ns < atomic.LoadInt64(&e.min) {
+		e.fastest = sample
+		atomic.StoreInt64(&e.min, ns)
+	}
+	if e.slowest == nil || ns > atomic.LoadInt64(&e.max) {
+		e.slowest = sample
+		atomic.StoreInt64(&e.max, ns)
+	}
+}
+
+// Extremes returns the fastest and slowest samples (nil before the first sample)
+func (e *latencyExtremes) Extremes() (fastest, slowest *TxSample) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.fastest, e.slowest
+}
+
+// Reset forgets all samples
+func (e *latencyExtremes) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	atomic.StoreInt64(&e.min, 0)
+	atomic.StoreInt64(&e.max, 0)
+	e.fastest, e.slowest = nil, nil
+}
@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Minimum time between two resyncs of the same account
+const nonceResyncCooldown = 5 * time.Second
+
+// noteNonceError counts a nonce error against the account's streak and, once the streak
+// passes nonce_resync_threshold, queues a resync. Atomic nonces normally make resyncs
+// unnecessary, but if something outside the benchmark moves the account's nonce (another
+// sender, a dropped tx), every send fails with "nonce too low" until it is re-read.
+func (b *Benchmark) noteNonceError(account *AccountSender) {
+	threshold := b.config.GetNonceResyncThreshold()
+	if threshold <= 0 {
+		return
+	}
+	if atomic.AddUint64(&account.nonceErrorStreak, 1) <= uint64(threshold) {
+		return
+	}
+
+	// Rate-limit per account: only the worker that claims the slot queues the resync
+	last := atomic.LoadInt64(&account.lastResync)
+	now := time.Now().UnixNano()
+	if now-last < int64(nonceResyncCooldown) || !atomic.CompareAndSwapInt64(&account.lastResync, last, now) {
+		return
+	}
+
+	select {
+	case b.resyncQueue <- account:
+	default: // Queue full, a later streak will retry
+	}
+}
+
+// nonceResyncWorker re-reads the pending nonce of accounts queued by noteNonceError
+func (b *Benchmark) nonceResyncWorker() {
+	for {
+		select {
+		case <-b.stopChan:
+			return
+		case account := <-b.resyncQueue:
+			streak := atomic.LoadUint64(&account.nonceErrorStreak)
+			before := account.CurrentNonce()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			err := account.ResyncNonce(ctx)
+			cancel()
+			if err != nil {
+				fmt.Printf("⚠️  Nonce resync for %s failed: %v\n", account.from.Hex(), err)
+				continue
+			}
+
+			atomic.StoreUint64(&account.nonceErrorStreak, 0)
+			atomic.AddUint64(&b.nonceResyncs, 1)
+			fmt.Printf("🔄 %s: %d consecutive nonce errors, resynced nonce %d → %d\n",
+				account.from.Hex(), streak, before, account.CurrentNonce())
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package internal
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+)
+
+// Pause before a worker restarts its send loop after a panic, so a panic on every
+// transaction doesn't turn into a busy loop
+const workerRestartDelay = 100 * time.Millisecond
+
+// recordPanic logs a recovered worker panic and counts it.
+// Only the first panic of the run prints a stack trace; later ones print a single line.
+func (b *Benchmark) recordPanic(id int, r interface{}) {
+	if atomic.AddUint64(&b.panicCount, 1) == 1 {
+		fmt.Printf("⚠️  Worker for account %d panicked: %v\n%s\n", id, r, debug.Stack())
+		return
+	}
+	fmt.Printf("⚠️  Worker for account %d panicked: %v\n", id, r)
+}
+
+// recoveredPanics returns how many worker panics were recovered during the run
+func (b *Benchmark) recoveredPanics() uint64 {
+	return atomic.LoadUint64(&b.panicCount)
+}
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Transfer patterns
+const (
+	PatternRoundRobin = "round-robin" // Account i → Account i+1
+	PatternFanOut     = "fan-out"     // A few distributor accounts → every other account
+)
+
+// validatePattern checks that the transfer pattern can run with the given account count
+func validatePattern(config *Config, numAccounts int) error {
+	switch config.TransferPattern {
+	case "", PatternRoundRobin:
+		return nil
+	case PatternFanOut:
+		distributors := config.FanOutSenders
+		if distributors <= 0 {
+			distributors = 1
+		}
+		if numAccounts <= distributors {
+			return fmt.Errorf("fan-out needs more accounts (%d) than distributors (%d)", numAccounts, distributors)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown transfer_pattern %q (use %q or %q)", config.TransferPattern, PatternRoundRobin, PatternFanOut)
+	}
+}
+
+// describePattern returns a one-line description for the configuration banner
+func (b *Benchmark) describePattern() string {
+	if b.config.TransferPattern == PatternFanOut {
+		return fmt.Sprintf("Fan-Out (%d distributor(s) → %d recipients)",
+			b.distributorCount(), len(b.accounts)-b.distributorCount())
+	}
+	return "Round-Robin (Account i → Account i+1)"
+}
+
+// distributorCount returns how many leading accounts send in fan-out mode
+func (b *Benchmark) distributorCount() int {
+	if b.config.TransferPattern != PatternFanOut {
+		return 0
+	}
+	if b.config.FanOutSenders <= 0 {
+		return 1
+	}
+	return b.config.FanOutSenders
+}
+
+// sendersForAccount returns how many concurrent workers send from an account.
+// In fan-out mode recipients stay idle and, unless fan_out_concurrency is set,
+// distributors take over the worker budget of the whole account set.
+func (b *Benchmark) sendersForAccount(accountID int) int {
+	concurrentSenders := b.config.ConcurrentSendersPerAccount
+	if concurrentSenders <= 0 {
+		concurrentSenders = 1 // Fallback to at least 1
+	}
+
+	if b.replay != nil {
+		if len(b.replayRows(accountID)) == 0 {
+			return 0 // No recorded transactions for this account
+		}
+		return concurrentSenders
+	}
+	if b.config.TransferPattern != PatternFanOut {
+		return concurrentSenders
+	}
+	if accountID >= b.distributorCount() {
+		return 0
+	}
+	if b.config.FanOutConcurrency > 0 {
+		return b.config.FanOutConcurrency
+	}
+	return concurrentSenders * len(b.accounts) / b.distributorCount()
+}
+
+// totalWorkers returns the number of sender goroutines the run will start
+func (b *Benchmark) totalWorkers() int {
+	if b.rotating() {
+		return b.config.MaxWorkers
+	}
+	return b.accountWorkers()
+}
+
+// accountWorkers returns the sum of sendersForAccount over all accounts
+func (b *Benchmark) accountWorkers() int {
+	total := 0
+	for i := range b.accounts {
+		total += b.sendersForAccount(i)
+	}
+	return total
+}
+
+// recipientFor picks the index of the account receiving the next transfer from accountID
+func (b *Benchmark) recipientFor(accountID int) int {
+	if b.config.TransferPattern == PatternFanOut {
+		distributors := b.distributorCount()
+		next := atomic.AddUint64(&b.fanOutCursor, 1) - 1
+		return distributors + int(next%uint64(len(b.accounts)-distributors))
+	}
+
+	// Round-robin: Account i sends to Account (i+1) % total_accounts
+	return (accountID + 1) % len(b.accounts)
+}
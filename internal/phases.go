@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Phases shorter than this (steps that had nothing to do) are not recorded
+const minPhaseDuration = time.Millisecond
+
+// Root frame of the folded stacks written to phase_trace_file
+const phaseTraceRoot = "u2u_benchmark"
+
+// PhaseTiming is the wall-clock time of one phase of the invocation
+type PhaseTiming struct {
+	Name         string  `json:"name"`
+	StartSeconds float64 `json:"start_seconds"` // Since the invocation started
+	Seconds      float64 `json:"seconds"`
+}
+
+// PhaseTimer splits an invocation into consecutive phases: each Begin ends the phase before it
+type PhaseTimer struct {
+	mu      sync.Mutex
+	start   time.Time
+	phases  []PhaseTiming
+	current string
+	since   time.Time
+}
+
+// NewPhaseTimer starts timing an invocation
+func NewPhaseTimer() *PhaseTimer {
+	return &PhaseTimer{start: time.Now()}
+}
+
+// Begin ends the running phase and starts the named one
+func (t *PhaseTimer) Begin(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	t.close(now)
+	t.current, t.since = name, now
+}
+
+// close records the running phase up to now (caller holds t.mu)
+func (t *PhaseTimer) close(now time.Time) {
+	if t.current == "" || now.Sub(t.since) < minPhaseDuration {
+		return
+	}
+	t.phases = append(t.phases, PhaseTiming{
+		Name:         t.current,
+		StartSeconds: t.since.Sub(t.start).Seconds(),
+		Seconds:      now.Sub(t.since).Seconds(),
+	})
+}
+
+// Phases returns the phases ended so far
+func (t *PhaseTimer) Phases() []PhaseTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]PhaseTiming(nil), t.phases...)
+}
+
+// SetPhaseTimer continues timing the phases of an invocation that began before the benchmark was created
+func (b *Benchmark) SetPhaseTimer(t *PhaseTimer) {
+	b.phases = t
+}
+
+// phaseTotal returns the time covered by the phases
+func phaseTotal(phases []PhaseTiming) float64 {
+	total := 0.0
+	for _, p := range phases {
+		total += p.Seconds
+	}
+	return total
+}
+
+// printPhaseReport shows where the invocation spent its time (trace_phases only)
+func (b *Benchmark) printPhaseReport() {
+	if !b.config.TracePhases {
+		return
+	}
+	phases := b.phases.Phases()
+	total := phaseTotal(phases)
+	if total <= 0 {
+		return
+	}
+	fmt.Printf("\n⏱️  Phases (%v until the report):\n", secondsDuration(total).Round(time.Millisecond))
+	for _, p := range phases {
+		share := p.Seconds / total
+		fmt.Printf("  %-20s%10v %5.1f%% %s\n", p.Name+":", secondsDuration(p.Seconds).Round(time.Millisecond),
+			share*100, strings.Repeat("█", int(share*30+0.5)))
+	}
+}
+
+// writePhaseTrace writes the phases as folded stacks ("u2u_benchmark;measure 60012", in
+// milliseconds), the input format of flamegraph.pl, inferno and speedscope
+func (b *Benchmark) writePhaseTrace() {
+	if b.config.PhaseTraceFile == "" {
+		return
+	}
+	var lines strings.Builder
+	for _, p := range b.phases.Phases() {
+		fmt.Fprintf(&lines, "%s;%s %d\n", phaseTraceRoot, p.Name, secondsDuration(p.Seconds).Milliseconds())
+	}
+	if err := os.WriteFile(b.config.PhaseTraceFile, []byte(lines.String()), 0644); err != nil {
+		fmt.Printf("Failed to write phase trace: %v\n", err)
+		return
+	}
+	fmt.Printf("📝 Phase trace saved to %s\n", b.config.PhaseTraceFile)
+}
+
+// secondsDuration converts seconds to a time.Duration
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
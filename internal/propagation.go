@@ -0,0 +1,345 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+	"github.com/unicornultrafoundation/go-u2u/rpc"
+)
+
+// With track_propagation and rpc_urls, a sample of the submitted transactions is looked up on
+// every endpoint other than the one it was sent to (eth_getTransactionByHash, which also finds
+// pending transactions). The time from the origin accepting the transaction until another node
+// returns it is that node's propagation latency, accurate to one propagation_poll_ms.
+
+// Lookups per JSON-RPC batch when polling an endpoint
+const propagationBatchSize = 100
+
+// propagationSample is one watched transaction
+type propagationSample struct {
+	hash     common.Hash
+	origin   int // Endpoint it was submitted to
+	sentAt   time.Time
+	seen     []bool // By endpoint (guarded by propagationTracker.mu)
+	resolved int    // Endpoints that saw it or timed out
+}
+
+// propagationPair accumulates the latencies from one origin endpoint to one target (guarded by propagationTracker.mu)
+type propagationPair struct {
+	histogram latencyHistogram
+	total     time.Duration
+	seen      uint64
+	missing   uint64 // Not seen within propagation_timeout_seconds
+}
+
+// propagationTracker samples sends and polls the other endpoints for them
+type propagationTracker struct {
+	urls     []string
+	clients  []*rpc.Client
+	batch    []bool // By endpoint: answered the JSON-RPC batch probe
+	every    uint64 // Sample one send in this many
+	poll     time.Duration
+	timeout  time.Duration
+	counter  uint64 // Sends offered (atomic)
+	sampled  uint64 // (atomic)
+	pollErrs uint64 // Failed lookups (atomic)
+
+	mu      sync.Mutex
+	pending []*propagationSample
+	pairs   [][]*propagationPair // [origin][target]
+
+	stop chan struct{}
+	done sync.WaitGroup
+}
+
+// EnablePropagation connects to every endpoint for propagation tracking (track_propagation).
+// Needs at least two endpoints in rpc_url/rpc_urls; without them it returns an error.
+func (b *Benchmark) EnablePropagation(tlsConfig *tls.Config) error {
+	endpoints := b.config.Endpoints()
+	if len(endpoints) < 2 {
+		return fmt.Errorf("track_propagation needs at least two endpoints (rpc_url plus rpc_urls)")
+	}
+
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}, Timeout: 5 * time.Second}
+	t := &propagationTracker{
+		urls:    endpoints,
+		every:   uint64(100 / b.config.GetPropagationSamplePercent()),
+		poll:    b.config.GetPropagationPollInterval(),
+		timeout: b.config.GetPropagationTimeout(),
+		pairs:   make([][]*propagationPair, len(endpoints)),
+		stop:    make(chan struct{}),
+	}
+	if t.every < 1 {
+		t.every = 1
+	}
+	for e, url := range endpoints {
+		client, err := rpc.DialHTTPWithClient(url, httpClient)
+		if err != nil {
+			t.close()
+			return fmt.Errorf("failed to connect to %s: %v", url, err)
+		}
+		t.clients = append(t.clients, client)
+
+		// rpc_url was probed at startup; look up the others one call at a time unless they take batches too
+		supported := b.batchSupported
+		if e > 0 {
+			supported = probeBatchSupport(context.Background(), url, tlsConfig) == nil
+		}
+		t.batch = append(t.batch, supported)
+		t.pairs[e] = make([]*propagationPair, len(endpoints))
+		for target := range endpoints {
+			t.pairs[e][target] = &propagationPair{}
+		}
+	}
+	b.propagation = t
+	fmt.Printf("  Propagation Tracking: 1 in %d sends looked up on the other %d endpoints every %v\n",
+		t.every, len(endpoints)-1, t.poll)
+	return nil
+}
+
+// Offer samples one accepted send (cheap for the sends that are not sampled)
+func (t *propagationTracker) Offer(hash common.Hash, origin int) {
+	if atomic.AddUint64(&t.counter, 1)%t.every != 0 {
+		return
+	}
+	atomic.AddUint64(&t.sampled, 1)
+	sample := &propagationSample{hash: hash, origin: origin, sentAt: time.Now(), seen: make([]bool, len(t.urls))}
+	sample.seen[origin] = true
+	sample.resolved = 1
+
+	t.mu.Lock()
+	t.pending = append(t.pending, sample)
+	t.mu.Unlock()
+}
+
+// Start begins polling every endpoint
+func (t *propagationTracker) Start() {
+	for target := range t.urls {
+		t.done.Add(1)
+		go t.pollLoop(target)
+	}
+}
+
+// pollLoop looks up the pending samples on one endpoint until stopped
+func (t *propagationTracker) pollLoop(target int) {
+	defer t.done.Done()
+	ticker := time.NewTicker(t.poll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.pollOnce(target)
+		}
+	}
+}
+
+// pollOnce checks the samples not yet seen on target
+func (t *propagationTracker) pollOnce(target int) {
+	var waiting []*propagationSample
+	t.mu.Lock()
+	for _, s := range t.pending {
+		if !s.seen[target] {
+			waiting = append(waiting, s)
+		}
+	}
+	t.mu.Unlock()
+
+	for start := 0; start < len(waiting); start += propagationBatchSize {
+		batch := waiting[start:min(start+propagationBatchSize, len(waiting))]
+		found, err := t.lookup(target, batch)
+		now := time.Now()
+		if err != nil {
+			atomic.AddUint64(&t.pollErrs, 1)
+		}
+
+		t.mu.Lock()
+		for i, s := range batch {
+			pair := t.pairs[s.origin][target]
+			switch {
+			case s.seen[target]:
+			case found != nil && found[i]:
+				latency := now.Sub(s.sentAt)
+				pair.histogram.Record(latency)
+				pair.total += latency
+				pair.seen++
+				s.seen[target] = true
+				s.resolved++
+			case now.Sub(s.sentAt) > t.timeout:
+				pair.missing++
+				s.seen[target] = true // Give up on this endpoint
+				s.resolved++
+			}
+		}
+		t.prune()
+		t.mu.Unlock()
+	}
+}
+
+// prune drops the samples every endpoint has resolved (caller holds t.mu)
+func (t *propagationTracker) prune() {
+	kept := t.pending[:0]
+	for _, s := range t.pending {
+		if s.resolved < len(t.urls) {
+			kept = append(kept, s)
+		}
+	}
+	t.pending = kept
+}
+
+// errBatchUnsupported sends lookups on an endpoint without batch support down the single-call path
+var errBatchUnsupported = errors.New("JSON-RPC batches not supported")
+
+// lookup reports which of the samples target knows, in one batch (or single calls when the
+// endpoint failed the batch probe or rejects this batch)
+func (t *propagationTracker) lookup(target int, samples []*propagationSample) ([]bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results := make([]json.RawMessage, len(samples))
+	batch := make([]rpc.BatchElem, len(samples))
+	for i, s := range samples {
+		batch[i] = rpc.BatchElem{Method: "eth_getTransactionByHash", Args: []interface{}{s.hash}, Result: &results[i]}
+	}
+	err := errBatchUnsupported
+	if t.batch[target] {
+		err = t.clients[target].BatchCallContext(ctx, batch)
+	}
+	if err != nil {
+		for i, s := range samples {
+			if err = t.clients[target].CallContext(ctx, &results[i], "eth_getTransactionByHash", s.hash); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	found := make([]bool, len(samples))
+	for i := range samples {
+		found[i] = batch[i].Error == nil && len(results[i]) > 0 && string(results[i]) != "null"
+	}
+	return found, nil
+}
+
+// Stop keeps polling until every sample is resolved or has timed out, then closes the clients
+func (t *propagationTracker) Stop() {
+	deadline := time.Now().Add(t.timeout + t.poll)
+	for time.Now().Before(deadline) {
+		t.mu.Lock()
+		remaining := len(t.pending)
+		t.mu.Unlock()
+		if remaining == 0 {
+			break
+		}
+		time.Sleep(t.poll)
+	}
+	close(t.stop)
+	t.done.Wait()
+	t.close()
+}
+
+// Reset discards the samples taken so far (end of warmup)
+func (t *propagationTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = nil
+	atomic.StoreUint64(&t.sampled, 0)
+	atomic.StoreUint64(&t.pollErrs, 0)
+	for _, row := range t.pairs {
+		for target := range row {
+			row[target] = &propagationPair{}
+		}
+	}
+}
+
+func (t *propagationTracker) close() {
+	for _, client := range t.clients {
+		client.Close()
+	}
+}
+
+// PropagationPath is the propagation from one endpoint to another
+type PropagationPath struct {
+	From    string  `json:"from"`
+	To      string  `json:"to"`
+	Seen    uint64  `json:"seen"`
+	Missing uint64  `json:"missing"` // Not seen within propagation_timeout_seconds
+	AvgMs   float64 `json:"avg_ms"`
+	P95Ms   float64 `json:"p95_ms"`
+}
+
+// PropagationStats summarises the cross-node propagation of the sampled transactions
+type PropagationStats struct {
+	Sampled      uint64            `json:"sampled"`
+	Paths        []PropagationPath `json:"paths"`
+	AvgMs        float64           `json:"avg_ms"` // Over all paths
+	P95Ms        float64           `json:"p95_ms"`
+	LookupErrors uint64            `json:"lookup_errors,omitempty"`
+}
+
+// propagationStats returns the per-path latencies (nil without track_propagation)
+func (b *Benchmark) propagationStats() *PropagationStats {
+	t := b.propagation
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := &PropagationStats{Sampled: atomic.LoadUint64(&t.sampled), LookupErrors: atomic.LoadUint64(&t.pollErrs)}
+	var all latencyHistogram
+	var total time.Duration
+	var seen uint64
+	for origin, row := range t.pairs {
+		for target, pair := range row {
+			if origin == target || pair.seen+pair.missing == 0 {
+				continue
+			}
+			path := PropagationPath{From: t.urls[origin], To: t.urls[target], Seen: pair.seen, Missing: pair.missing}
+			if pair.seen > 0 {
+				path.AvgMs = float64(pair.total.Microseconds()) / float64(pair.seen) / 1000
+				path.P95Ms = float64(pair.histogram.Percentile(95).Microseconds()) / 1000
+			}
+			stats.Paths = append(stats.Paths, path)
+
+			for i := range pair.histogram.buckets {
+				all.buckets[i] += pair.histogram.buckets[i]
+			}
+			total += pair.total
+			seen += pair.seen
+		}
+	}
+	if seen > 0 {
+		stats.AvgMs = float64(total.Microseconds()) / float64(seen) / 1000
+		stats.P95Ms = float64(all.Percentile(95).Microseconds()) / 1000
+	}
+	return stats
+}
+
+// printPropagationReport shows how fast sampled transactions reached the other nodes
+func (b *Benchmark) printPropagationReport() {
+	stats := b.propagationStats()
+	if stats == nil {
+		return
+	}
+	fmt.Printf("\n📡 Propagation (%d sampled transactions):\n", stats.Sampled)
+	for _, path := range stats.Paths {
+		line := fmt.Sprintf("  %s → %s: avg %.0fms, p95 %.0fms (%d seen", path.From, path.To, path.AvgMs, path.P95Ms, path.Seen)
+		if path.Missing > 0 {
+			line += fmt.Sprintf(", ⚠️  %d not seen", path.Missing)
+		}
+		fmt.Println(line + ")")
+	}
+	fmt.Printf("  %-20savg %.0fms, p95 %.0fms (±%v poll interval)\n", "All Paths:", stats.AvgMs, stats.P95Ms, b.propagation.poll)
+	if stats.LookupErrors > 0 {
+		fmt.Printf("  ⚠️  %d lookups failed\n", stats.LookupErrors)
+	}
+}
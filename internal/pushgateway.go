@@ -0,0 +1,184 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Prefix of every exported metric name
+const metricPrefix = "u2u_benchmark_"
+
+// PrometheusText renders the final aggregates in the Prometheus text exposition format
+// (version 0.0.4, accepted by the pushgateway). Every sample carries the given labels.
+func (r *Results) PrometheusText(labels map[string]string) string {
+	var buf strings.Builder
+	base := formatLabels(labels, "", "")
+
+	gauge := func(name, help string, value float64) {
+		fmt.Fprintf(&buf, "# HELP %s%s %s\n", metricPrefix, name, help)
+		fmt.Fprintf(&buf, "# TYPE %s%s gauge\n", metricPrefix, name)
+		fmt.Fprintf(&buf, "%s%s%s %g\n", metricPrefix, name, base, value)
+	}
+	quantiles := func(name, help string, p50, p95, p99 int64) {
+		fmt.Fprintf(&buf, "# HELP %s%s %s\n", metricPrefix, name, help)
+		fmt.Fprintf(&buf, "# TYPE %s%s gauge\n", metricPrefix, name)
+		for _, q := range []struct {
+			quantile string
+			ms       int64
+		}{{"0.5", p50}, {"0.95", p95}, {"0.99", p99}} {
+			fmt.Fprintf(&buf, "%s%s%s %g\n", metricPrefix, name,
+				formatLabels(labels, "quantile", q.quantile), float64(q.ms)/1000)
+		}
+	}
+
+	if ts, err := time.Parse(time.RFC3339, r.Timestamp); err == nil {
+		gauge("run_timestamp_seconds", "Unix time the results were written.", float64(ts.Unix()))
+	}
+	if duration, ok := r.Config["duration_seconds"].(float64); ok {
+		gauge("duration_seconds", "Length of the measured send window.", duration)
+	}
+	gauge("submitted_total", "Transactions accepted by the RPC endpoint.", float64(r.TotalSubmitted))
+	gauge("errors_total", "Failed submissions.", float64(r.TotalErrors))
+	gauge("retries_total", "Extra submission attempts.", float64(r.TotalRetries))
+	gauge("nonce_errors_total", "Nonce-related rejections (not counted as errors).", float64(r.NonceErrors))
+	gauge("nonce_error_percent", "Share of send attempts rejected for their nonce.", r.NonceErrorPercent)
+	gauge("rate_limit_hits_total", "Responses rejected with HTTP 429.", float64(r.RateLimitHits))
+	gauge("rpc_accept_rate_percent", "Share of submissions accepted by the RPC endpoint.", r.RPCAcceptRate)
+	gauge("submitted_tps_avg", "Average submitted transactions per second.", r.AvgSubmittedTPS)
+	gauge("submitted_tps_peak", "Highest submitted TPS in one report interval.", float64(r.PeakSubmittedTPS))
+	gauge("submitted_tps_min", "Lowest submitted TPS in one report interval.", float64(r.MinSubmittedTPS))
+	gauge("submitted_tps_median", "Median submitted TPS across report intervals.", float64(r.MedianSubmittedTPS))
+	gauge("submit_latency_avg_seconds", "Average RPC submission latency.", float64(r.AvgLatencyMs)/1000)
+	quantiles("submit_latency_seconds", "RPC submission latency percentiles.", r.P50LatencyMs, r.P95LatencyMs, r.P99LatencyMs)
+	if spend, err := parseFloat(r.EstimatedSpendWei); err == nil {
+		gauge("estimated_spend_wei", "Estimated value plus gas spent.", spend)
+	}
+	if r.TotalConfirmed > 0 {
+		gauge("confirmed_total", "Transactions found in blocks.", float64(r.TotalConfirmed))
+		gauge("confirmed_tps_avg", "Average confirmed transactions per second.", r.AvgConfirmedTPS)
+		gauge("inflight_backlog_max", "Highest submitted-but-unconfirmed count.", float64(r.MaxInflightBacklog))
+	}
+	if r.TotalReverted > 0 {
+		gauge("reverted_total", "Confirmed transactions that reverted.", float64(r.TotalReverted))
+	}
+	if r.TotalFinalized > 0 {
+		gauge("finalized_total", "Transactions seen in a finalized block.", float64(r.TotalFinalized))
+		quantiles("finality_latency_seconds", "Submission-to-finality latency percentiles.", r.P50FinalityMs, r.P95FinalityMs, r.P99FinalityMs)
+	}
+	if r.BlocksProduced > 0 {
+		gauge("blocks_produced", "Blocks produced during the send window.", float64(r.BlocksProduced))
+	}
+	return buf.String()
+}
+
+// formatLabels renders {k="v",...} in key order, with an optional extra pair appended
+func formatLabels(labels map[string]string, extraKey, extraValue string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys)+1)
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, labels[k]))
+	}
+	if extraKey != "" {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", extraKey, extraValue))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// parseFloat parses a decimal integer string (e.g. a wei amount) as a float
+func parseFloat(s string) (float64, error) {
+	var f float64
+	_, err := fmt.Sscan(s, &f)
+	return f, err
+}
+
+// prometheusLabels are attached to every exported sample (and form the pushgateway grouping key)
+func (b *Benchmark) prometheusLabels() map[string]string {
+	labels := make(map[string]string)
+	if b.config.RunLabel != "" {
+		labels["run"] = b.config.RunLabel
+	}
+	if b.results != nil && b.results.ChainID != 0 {
+		labels["chain_id"] = fmt.Sprintf("%d", b.results.ChainID)
+	}
+	return labels
+}
+
+// exportPrometheus writes and/or pushes the final results when prometheus_file or pushgateway_url is set.
+// Failures are reported but do not fail the run; the JSON results are already on disk.
+func (b *Benchmark) exportPrometheus() {
+	if b.results == nil || (b.config.PrometheusFile == "" && b.config.PushgatewayURL == "") {
+		return
+	}
+	labels := b.prometheusLabels()
+	text := b.results.PrometheusText(labels)
+
+	if b.config.PrometheusFile != "" {
+		if err := os.WriteFile(b.config.PrometheusFile, []byte(text), 0644); err != nil {
+			fmt.Printf("⚠️  Failed to write Prometheus metrics: %v\n", err)
+		} else {
+			fmt.Printf("📝 Prometheus metrics saved to %s\n", b.config.PrometheusFile)
+		}
+	}
+
+	if b.config.PushgatewayURL != "" {
+		if err := pushMetrics(b.config.PushgatewayURL, b.config.GetPushgatewayJob(), labels, text); err != nil {
+			fmt.Printf("⚠️  Failed to push metrics to %s: %v\n", b.config.PushgatewayURL, err)
+		} else {
+			fmt.Printf("📤 Metrics pushed to %s (job %q)\n", b.config.PushgatewayURL, b.config.GetPushgatewayJob())
+		}
+	}
+}
+
+// groupingPair encodes one label of the pushgateway grouping key as a URL path segment.
+// Values containing a slash use the gateway's base64 form, since %2F is not reliably preserved.
+func groupingPair(key, value string) string {
+	if strings.Contains(value, "/") {
+		return key + "@base64/" + base64.RawURLEncoding.EncodeToString([]byte(value))
+	}
+	return key + "/" + url.PathEscape(value)
+}
+
+// pushMetrics replaces the pushgateway's metric group for job and labels with text
+func pushMetrics(gateway, job string, labels map[string]string, text string) error {
+	endpoint := strings.TrimRight(gateway, "/") + "/metrics/" + groupingPair("job", job)
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		endpoint += "/" + groupingPair(k, labels[k])
+	}
+
+	req, err := http.NewRequest(http.MethodPut, endpoint, bytes.NewBufferString(text))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("pushgateway returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,381 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+	"github.com/unicornultrafoundation/go-u2u/core/types"
+	"github.com/unicornultrafoundation/go-u2u/ethclient"
+)
+
+// receiptWatcher tracks inclusion of submitted transactions.
+// Rather than polling one receipt per transaction, it scans each new block
+// and matches its transaction hashes against the pending set, so the RPC
+// cost is one call per block regardless of TPS. Receipts (one call per
+// confirmed transaction) are only fetched when reverts are tracked.
+//
+// With a confirmation depth of N, a transaction only counts as confirmed once
+// its block is N blocks below the head. Until then its block waits in the
+// shallow queue; when it reaches depth its hash is re-checked against the
+// canonical chain (one header call per block), and if it was reorged out its
+// transactions go back to pending and scanning resumes from that height.
+type receiptWatcher struct {
+	client       *ethclient.Client
+	pollInterval time.Duration
+	depth        uint64 // Blocks required on top of the including block
+
+	mu      sync.Mutex
+	pending map[common.Hash]pendingTx
+	shallow []includedBlock // Scanned blocks not yet at depth, oldest first
+
+	included         uint64 // atomic: seen in a block (depth 0), including shallow ones
+	reorged          uint64 // atomic: included, then dropped by a reorg before reaching depth
+	confirmed        uint64 // atomic
+	confirmLatencies latencyHistogram
+	confirmExtremes  latencyExtremes
+
+	// Per fee group (index = group passed to Track)
+	groupConfirmed []uint64 // atomic
+	groupLatencies []latencyHistogram
+
+	// Per transaction type of a workload mix (index = txType passed to Track)
+	typeConfirmed []uint64 // atomic
+
+	// Finality latency (nil unless track_finality is set and the node supports it)
+	finality *finalityTracker
+
+	// Gas use of every scanned block (nil unless target_block_utilization is set)
+	utilization *utilizationController
+
+	// Receipt status checks (only when trackReverts is set)
+	trackReverts       bool
+	receiptQueue       chan common.Hash
+	statusChecked      uint64 // atomic
+	reverted           uint64 // atomic
+	fetchers           sync.WaitGroup
+	nextBlock          uint64
+	lastPollErrorPrint time.Time
+
+	stopChan chan struct{}
+	done     chan struct{}
+}
+
+// pendingTx is a tracked transaction waiting for inclusion
+type pendingTx struct {
+	submitted time.Time
+	group     int // Fee group (0 without gas_price_multipliers)
+	txType    int // Type of a workload mix (0 without workload_mix)
+}
+
+// includedBlock is a scanned block whose tracked transactions await confirmation depth
+type includedBlock struct {
+	number uint64
+	hash   common.Hash
+	txs    map[common.Hash]pendingTx
+}
+
+// Number of goroutines fetching receipts when reverts are tracked
+const receiptFetchers = 8
+
+func newReceiptWatcher(client *ethclient.Client, pollInterval time.Duration, depth uint64, trackReverts bool, groups, txTypes int) *receiptWatcher {
+	if pollInterval <= 0 {
+		pollInterval = 500 * time.Millisecond
+	}
+	return &receiptWatcher{
+		client:       client,
+		pollInterval: pollInterval,
+		depth:        depth,
+		pending:      make(map[common.Hash]pendingTx),
+		trackReverts: trackReverts,
+		receiptQueue: make(chan common.Hash, 10000),
+		stopChan:     make(chan struct{}),
+		done:         make(chan struct{}),
+
+		groupConfirmed: make([]uint64, groups),
+		groupLatencies: make([]latencyHistogram, groups),
+		typeConfirmed:  make([]uint64, txTypes),
+	}
+}
+
+// Start begins scanning from the block after the current head
+func (w *receiptWatcher) Start(ctx context.Context) error {
+	head, err := w.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block number: %v", err)
+	}
+	w.nextBlock = head + 1
+
+	if w.trackReverts {
+		for i := 0; i < receiptFetchers; i++ {
+			w.fetchers.Add(1)
+			go w.fetchReceipts(ctx)
+		}
+	}
+
+	go w.run(ctx)
+	return nil
+}
+
+// Track registers a transaction before it is sent, so it can't be missed
+// if its block is scanned before the send call returns.
+func (w *receiptWatcher) Track(hash common.Hash, submitted time.Time, group, txType int) {
+	w.mu.Lock()
+	w.pending[hash] = pendingTx{submitted: submitted, group: group, txType: txType}
+	w.mu.Unlock()
+}
+
+// TypeConfirmed returns the confirmed count of one workload mix type
+func (w *receiptWatcher) TypeConfirmed(txType int) uint64 {
+	if txType >= len(w.typeConfirmed) {
+		return 0
+	}
+	return atomic.LoadUint64(&w.typeConfirmed[txType])
+}
+
+// Forget drops a transaction whose submission failed
+func (w *receiptWatcher) Forget(hash common.Hash) {
+	w.mu.Lock()
+	delete(w.pending, hash)
+	w.mu.Unlock()
+}
+
+// Reset forgets all tracked transactions and zeroes the counters (end of warmup)
+func (w *receiptWatcher) Reset() {
+	w.mu.Lock()
+	w.pending = make(map[common.Hash]pendingTx)
+	w.shallow = nil
+	w.mu.Unlock()
+
+	for i := range w.groupConfirmed {
+		atomic.StoreUint64(&w.groupConfirmed[i], 0)
+		w.groupLatencies[i].Reset()
+	}
+	for i := range w.typeConfirmed {
+		atomic.StoreUint64(&w.typeConfirmed[i], 0)
+	}
+
+	atomic.StoreUint64(&w.included, 0)
+	atomic.StoreUint64(&w.reorged, 0)
+	atomic.StoreUint64(&w.confirmed, 0)
+	atomic.StoreUint64(&w.statusChecked, 0)
+	atomic.StoreUint64(&w.reverted, 0)
+	w.confirmLatencies.Reset()
+	w.confirmExtremes.Reset()
+	if w.finality != nil {
+		w.finality.Reset()
+	}
+}
+
+// Confirmed returns the number of tracked transactions whose block reached the confirmation depth
+func (w *receiptWatcher) Confirmed() uint64 {
+	return atomic.LoadUint64(&w.confirmed)
+}
+
+// Included returns the number of tracked transactions seen in a block at any depth
+func (w *receiptWatcher) Included() uint64 {
+	return atomic.LoadUint64(&w.included)
+}
+
+// Reorged returns how many included transactions were dropped by a reorg before reaching depth
+func (w *receiptWatcher) Reorged() uint64 {
+	return atomic.LoadUint64(&w.reorged)
+}
+
+// Pending returns the number of tracked transactions not yet confirmed
+// (not seen in a block, or in a block that hasn't reached the confirmation depth)
+func (w *receiptWatcher) Pending() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	pending := len(w.pending)
+	for _, block := range w.shallow {
+		pending += len(block.txs)
+	}
+	return pending
+}
+
+// Reverted returns how many confirmed transactions had status 0, out of those checked
+func (w *receiptWatcher) Reverted() (reverted, checked uint64) {
+	return atomic.LoadUint64(&w.reverted), atomic.LoadUint64(&w.statusChecked)
+}
+
+// Stop ends scanning and waits for the current poll and queued receipt checks to finish
+func (w *receiptWatcher) Stop() {
+	close(w.stopChan)
+	<-w.done
+	close(w.receiptQueue)
+	w.fetchers.Wait()
+	if w.finality != nil {
+		w.finality.Close()
+	}
+}
+
+// fetchReceipts checks the status of confirmed transactions
+func (w *receiptWatcher) fetchReceipts(ctx context.Context) {
+	defer w.fetchers.Done()
+
+	for hash := range w.receiptQueue {
+		receipt, err := w.client.TransactionReceipt(ctx, hash)
+		if err != nil {
+			continue
+		}
+		atomic.AddUint64(&w.statusChecked, 1)
+		if receipt.Status == types.ReceiptStatusFailed {
+			atomic.AddUint64(&w.reverted, 1)
+		}
+	}
+}
+
+func (w *receiptWatcher) run(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ticker.C:
+			if err := w.poll(ctx); err != nil && time.Since(w.lastPollErrorPrint) > 10*time.Second {
+				// Rate-limit the message; the next tick retries from the same block
+				fmt.Printf("⚠️  Receipt watcher: %v\n", err)
+				w.lastPollErrorPrint = time.Now()
+			}
+		}
+	}
+}
+
+// poll scans every block between the last scanned block and the current head,
+// then confirms the scanned blocks that have reached the confirmation depth
+func (w *receiptWatcher) poll(ctx context.Context) error {
+	head, err := w.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block number: %v", err)
+	}
+
+	for ; w.nextBlock <= head; w.nextBlock++ {
+		block, err := w.client.BlockByNumber(ctx, new(big.Int).SetUint64(w.nextBlock))
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %v", w.nextBlock, err)
+		}
+
+		if w.utilization != nil {
+			w.utilization.observe(block.GasUsed(), block.GasLimit())
+		}
+
+		included := includedBlock{number: w.nextBlock, hash: block.Hash(), txs: make(map[common.Hash]pendingTx)}
+		w.mu.Lock()
+		for _, tx := range block.Transactions() {
+			tracked, ok := w.pending[tx.Hash()]
+			if !ok {
+				continue
+			}
+			delete(w.pending, tx.Hash())
+			included.txs[tx.Hash()] = tracked
+		}
+		if len(included.txs) > 0 {
+			w.shallow = append(w.shallow, included)
+			atomic.AddUint64(&w.included, uint64(len(included.txs)))
+		}
+		w.mu.Unlock()
+	}
+
+	if err := w.confirmDeep(ctx, head); err != nil {
+		return err
+	}
+	if w.finality != nil {
+		return w.finality.poll(ctx)
+	}
+	return nil
+}
+
+// confirmDeep counts the transactions of every shallow block at least depth blocks below head.
+// A block whose hash no longer matches the canonical chain was reorged out: it and every
+// later shallow block go back to pending and are scanned again.
+func (w *receiptWatcher) confirmDeep(ctx context.Context, head uint64) error {
+	for {
+		w.mu.Lock()
+		if len(w.shallow) == 0 || w.shallow[0].number+w.depth > head {
+			w.mu.Unlock()
+			return nil
+		}
+		block := w.shallow[0]
+		w.mu.Unlock()
+
+		// At depth 0 the block was fetched in this poll, nothing can have replaced it yet
+		if w.depth > 0 {
+			header, err := w.client.HeaderByNumber(ctx, new(big.Int).SetUint64(block.number))
+			if err != nil {
+				return fmt.Errorf("failed to get header %d: %v", block.number, err)
+			}
+			if header.Hash() != block.hash {
+				w.requeueFrom(block.number)
+				return nil
+			}
+		}
+
+		now := time.Now()
+		w.mu.Lock()
+		if len(w.shallow) == 0 || w.shallow[0].number != block.number {
+			// Reset (end of warmup) dropped it meanwhile
+			w.mu.Unlock()
+			continue
+		}
+		w.shallow = w.shallow[1:]
+		w.mu.Unlock()
+		if w.finality != nil {
+			submitted := make([]time.Time, 0, len(block.txs))
+			for _, tracked := range block.txs {
+				submitted = append(submitted, tracked.submitted)
+			}
+			w.finality.add(block.number, submitted)
+		}
+		for hash, tracked := range block.txs {
+			latency := now.Sub(tracked.submitted)
+			atomic.AddUint64(&w.confirmed, 1)
+			w.confirmLatencies.Record(latency)
+			w.confirmExtremes.Record(latency, hash, -1)
+			if tracked.group < len(w.groupConfirmed) {
+				atomic.AddUint64(&w.groupConfirmed[tracked.group], 1)
+				w.groupLatencies[tracked.group].Record(latency)
+			}
+			if tracked.txType < len(w.typeConfirmed) {
+				atomic.AddUint64(&w.typeConfirmed[tracked.txType], 1)
+			}
+			if w.trackReverts {
+				w.receiptQueue <- hash
+			}
+		}
+	}
+}
+
+// requeueFrom returns the transactions of shallow blocks at or above number to pending
+// and rewinds scanning to that height
+func (w *receiptWatcher) requeueFrom(number uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	kept := w.shallow[:0]
+	for _, block := range w.shallow {
+		if block.number < number {
+			kept = append(kept, block)
+			continue
+		}
+		for hash, tracked := range block.txs {
+			w.pending[hash] = tracked
+		}
+		atomic.AddUint64(&w.reorged, uint64(len(block.txs)))
+		atomic.AddUint64(&w.included, ^uint64(len(block.txs)-1))
+	}
+	w.shallow = kept
+
+	if number < w.nextBlock {
+		w.nextBlock = number
+	}
+	fmt.Printf("⚠️  Receipt watcher: block %d was reorged out, rescanning from there\n", number)
+}
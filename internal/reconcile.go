@@ -0,0 +1,207 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unicornultrafoundation/go-u2u/rpc"
+)
+
+// Nonce reconciliation modes (nonce_reconcile)
+const (
+	ReconcileIgnore    = "ignore"     // Start from the pending nonce as usual (default)
+	ReconcileWait      = "wait"       // Wait for the accounts' pooled txs to clear first
+	ReconcileSkipAhead = "skip-ahead" // Start after the executable txs already in the pool
+)
+
+// txpoolContent is the part of a txpool_content response needed here:
+// address -> nonce -> transaction, for executable (pending) and gapped (queued) txs
+type txpoolContent struct {
+	Pending map[string]map[string]json.RawMessage `json:"pending"`
+	Queued  map[string]map[string]json.RawMessage `json:"queued"`
+}
+
+// pooledTxs are the nonces one account has in the txpool
+type pooledTxs struct {
+	pending []uint64 // Executable
+	queued  []uint64 // Behind a nonce gap
+}
+
+// count returns the number of pooled transactions
+func (p pooledTxs) count() int {
+	return len(p.pending) + len(p.queued)
+}
+
+// pooledNonces returns, per lower-case address, the nonces the node holds in its txpool
+func (c *txpoolContent) pooledNonces() map[string]pooledTxs {
+	pooled := make(map[string]pooledTxs)
+	add := func(section map[string]map[string]json.RawMessage, queued bool) {
+		for address, txs := range section {
+			key := strings.ToLower(address)
+			p := pooled[key]
+			for nonceStr := range txs {
+				nonce, err := strconv.ParseUint(nonceStr, 10, 64)
+				if err != nil {
+					continue
+				}
+				if queued {
+					p.queued = append(p.queued, nonce)
+				} else {
+					p.pending = append(p.pending, nonce)
+				}
+			}
+			pooled[key] = p
+		}
+	}
+	add(c.Pending, false)
+	add(c.Queued, true)
+	return pooled
+}
+
+// skipPending returns the nonce after the contiguous run of pending nonces that starts at next
+// (next itself when the pool has no executable tx at that nonce)
+func skipPending(next uint64, pending []uint64) uint64 {
+	held := make(map[uint64]bool, len(pending))
+	for _, n := range pending {
+		held[n] = true
+	}
+	for held[next] {
+		next++
+	}
+	return next
+}
+
+// ReconcileNonces checks the node's txpool for transactions left over from earlier runs
+// and adjusts the accounts' starting nonces according to config.NonceReconcile.
+func ReconcileNonces(ctx context.Context, config *Config, accounts []*AccountSender) error {
+	mode := config.NonceReconcile
+	if mode == "" || mode == ReconcileIgnore {
+		return nil
+	}
+	if mode != ReconcileWait && mode != ReconcileSkipAhead {
+		return fmt.Errorf("unknown nonce_reconcile mode %q (use %q, %q or %q)",
+			mode, ReconcileIgnore, ReconcileWait, ReconcileSkipAhead)
+	}
+
+	// Each account is checked against the pool of the endpoint it is pinned to (rpc_urls)
+	endpoints := config.Endpoints()
+	clients := make([]*rpc.Client, len(endpoints))
+	defer func() {
+		for _, client := range clients {
+			if client != nil {
+				client.Close()
+			}
+		}
+	}()
+	for _, account := range accounts {
+		if clients[account.endpoint] != nil {
+			continue
+		}
+		client, err := config.dialRPC(ctx, endpoints[account.endpoint])
+		if err != nil {
+			return fmt.Errorf("failed to dial %s for txpool: %v", endpoints[account.endpoint], err)
+		}
+		clients[account.endpoint] = client
+	}
+
+	fmt.Printf("\n🧹 Reconciling nonces with the txpool (%s)...\n", mode)
+
+	pooled, err := fetchPooledNonces(ctx, clients, accounts)
+	if err != nil {
+		fmt.Printf("⚠️  txpool_content unavailable (%v), skipping nonce reconciliation\n", err)
+		return nil
+	}
+	if len(pooled) == 0 {
+		fmt.Println("✅ No pooled transactions from benchmark accounts")
+		return nil
+	}
+
+	// Skip-ahead mode: only past executable txs; jumping past queued ones would keep their
+	// gap open and queue every later send behind it
+	if mode == ReconcileSkipAhead {
+		for i, account := range accounts {
+			txs := pooled[strings.ToLower(account.from.Hex())]
+			if txs.count() == 0 {
+				continue
+			}
+			current := account.CurrentNonce()
+			if next := skipPending(current, txs.pending); next > current {
+				fmt.Printf("   Account %d: %d pending txs, skipping ahead from nonce %d to %d\n",
+					i, next-current, current, next)
+				account.SetNonce(next)
+			}
+			if len(txs.queued) > 0 {
+				fmt.Printf("⚠️  Account %d: %d queued txs behind a nonce gap; the run fills the gap from nonce %d and may replace them\n",
+					i, len(txs.queued), account.CurrentNonce())
+			}
+		}
+		return nil
+	}
+
+	// Wait mode: poll until none of the accounts has pooled transactions
+	timeout := time.Duration(config.NonceReconcileTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = 60 * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+	for len(pooled) > 0 {
+		total := 0
+		for _, txs := range pooled {
+			total += txs.count()
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%d pooled txs from %d accounts did not clear within %v", total, len(pooled), timeout)
+		}
+		fmt.Printf("   Waiting for %d pooled txs from %d accounts to clear...\n", total, len(pooled))
+		time.Sleep(2 * time.Second)
+
+		pooled, err = fetchPooledNonces(ctx, clients, accounts)
+		if err != nil {
+			return fmt.Errorf("failed to read txpool: %v", err)
+		}
+	}
+
+	// Pool is clear: start every account from its fresh pending nonce plus any nonce_offset
+	// (no_preflight keeps its start_nonce as is)
+	if config.NoPreflight {
+		fmt.Println("✅ Txpool clear, keeping the preset start nonces")
+		return nil
+	}
+	for i, account := range accounts {
+		if err := account.ResyncNonce(ctx); err != nil {
+			return fmt.Errorf("failed to resync nonce for account %d: %v", i, err)
+		}
+		if config.NonceOffset != 0 {
+			account.SetNonce(applyNonceOffset(account.CurrentNonce(), config.NonceOffset))
+		}
+	}
+	fmt.Println("✅ Txpool clear, nonces resynced")
+	return nil
+}
+
+// fetchPooledNonces returns the pooled nonces of the benchmark accounts only, each read
+// from the pool of its own endpoint (clients is indexed like Config.Endpoints)
+func fetchPooledNonces(ctx context.Context, clients []*rpc.Client, accounts []*AccountSender) (map[string]pooledTxs, error) {
+	pools := make(map[int]map[string]pooledTxs)
+	ours := make(map[string]pooledTxs)
+	for _, account := range accounts {
+		all, ok := pools[account.endpoint]
+		if !ok {
+			var content txpoolContent
+			if err := clients[account.endpoint].CallContext(ctx, &content, "txpool_content"); err != nil {
+				return nil, err
+			}
+			all = content.pooledNonces()
+			pools[account.endpoint] = all
+		}
+		key := strings.ToLower(account.from.Hex())
+		if txs := all[key]; txs.count() > 0 {
+			ours[key] = txs
+		}
+	}
+	return ours, nil
+}
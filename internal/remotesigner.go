@@ -0,0 +1,220 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+	"github.com/unicornultrafoundation/go-u2u/common/hexutil"
+	"github.com/unicornultrafoundation/go-u2u/core/types"
+	"github.com/unicornultrafoundation/go-u2u/ethclient"
+	"github.com/unicornultrafoundation/go-u2u/rpc"
+)
+
+// RemoteSigner signs transactions through a Clef-compatible account_signTransaction
+// endpoint, so private keys never enter the benchmark process.
+type RemoteSigner struct {
+	rpc     *rpc.Client
+	url     string
+	timeout time.Duration
+}
+
+// signTxArgs is the transaction object account_signTransaction expects
+type signTxArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                hexutil.Big     `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId,omitempty"`
+}
+
+// signTxResult is the reply of account_signTransaction (the decoded tx is ignored)
+type signTxResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// NewRemoteSigner connects to the signer endpoint (tlsConfig: see Config.TLSConfig)
+func NewRemoteSigner(ctx context.Context, url string, tlsConfig *tls.Config) (*RemoteSigner, error) {
+	client, err := DialRPC(ctx, url, tlsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to remote signer: %v", err)
+	}
+	return &RemoteSigner{rpc: client, url: url, timeout: 30 * time.Second}, nil
+}
+
+// SignTx asks the signer to sign tx for from and decodes the signed transaction
+func (s *RemoteSigner) SignTx(ctx context.Context, from common.Address, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	args := signTxArgs{
+		From:    from,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var result signTxResult
+	if err := s.rpc.CallContext(ctx, &result, "account_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("remote signer: %v", err)
+	}
+	if len(result.Raw) == 0 {
+		return nil, fmt.Errorf("remote signer returned no signed transaction")
+	}
+
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(result.Raw); err != nil {
+		return nil, fmt.Errorf("remote signer returned an invalid transaction: %v", err)
+	}
+	if signed.Nonce() != tx.Nonce() {
+		return nil, fmt.Errorf("remote signer changed the nonce (%d → %d)", tx.Nonce(), signed.Nonce())
+	}
+	return signed, nil
+}
+
+// Accounts lists the addresses the signer manages (account_list)
+func (s *RemoteSigner) Accounts(ctx context.Context) ([]common.Address, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var accounts []common.Address
+	if err := s.rpc.CallContext(ctx, &accounts, "account_list"); err != nil {
+		return nil, fmt.Errorf("remote signer: %v", err)
+	}
+	return accounts, nil
+}
+
+// InitializeRemoteAccounts sets up senders whose transactions are signed by the remote signer.
+// The addresses come from remote_signer_accounts, or from the signer's account_list when empty.
+func InitializeRemoteAccounts(client *ethclient.Client, config *Config) ([]*AccountSender, error) {
+	ctx := context.Background()
+
+	tlsConfig, err := config.tlsSettings()
+	if err != nil {
+		return nil, err
+	}
+	signer, err := NewRemoteSigner(ctx, config.RemoteSignerURL, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []common.Address
+	for _, addr := range config.RemoteSignerAccounts {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("remote_signer_accounts: invalid address %q", addr)
+		}
+		addresses = append(addresses, common.HexToAddress(addr))
+	}
+	if len(addresses) == 0 {
+		fmt.Printf("Asking the remote signer for its accounts (approve account_list on the signer)...\n")
+		if addresses, err = signer.Accounts(ctx); err != nil {
+			return nil, err
+		}
+		if len(addresses) == 0 {
+			return nil, fmt.Errorf("remote signer has no accounts")
+		}
+	}
+	if config.NumAccounts > 0 && config.NumAccounts < len(addresses) {
+		fmt.Printf("Using %d out of %d remote signer accounts (as per config)\n", config.NumAccounts, len(addresses))
+		addresses = addresses[:config.NumAccounts]
+	}
+
+	fmt.Printf("✍️  Remote signer: %s (%d accounts, keys stay with the signer)\n", config.RemoteSignerURL, len(addresses))
+	accounts, err := initializeSenders(ctx, client, addresses, config)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts {
+		account.signer = signer
+	}
+	return accounts, nil
+}
+
+// Sign signs tx with the account's key, or through the remote signer when one is set
+func (a *AccountSender) Sign(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	if a.signer != nil {
+		return a.signer.SignTx(ctx, a.from, tx, a.chainID)
+	}
+	return SignTransaction(tx, a.chainID, a.privateKey)
+}
+
+// signingStats measures the time spent in remote signing calls
+type signingStats struct {
+	count     uint64 // atomic
+	totalNs   int64  // atomic
+	latencies latencyHistogram
+}
+
+// Record adds one remote signing call
+func (s *signingStats) Record(d time.Duration) {
+	atomic.AddUint64(&s.count, 1)
+	atomic.AddInt64(&s.totalNs, d.Nanoseconds())
+	s.latencies.Record(d)
+}
+
+// Average returns the mean signing latency
+func (s *signingStats) Average() time.Duration {
+	count := atomic.LoadUint64(&s.count)
+	if count == 0 {
+		return 0
+	}
+	return time.Duration(atomic.LoadInt64(&s.totalNs) / int64(count))
+}
+
+// Reset clears all samples (end of warmup)
+func (s *signingStats) Reset() {
+	atomic.StoreUint64(&s.count, 0)
+	atomic.StoreInt64(&s.totalNs, 0)
+	s.latencies.Reset()
+}
+
+// printSigningReport shows the remote signing overhead, which is part of every send latency
+func (b *Benchmark) printSigningReport(avgLatency time.Duration) {
+	if b.config.RemoteSignerURL == "" || atomic.LoadUint64(&b.signing.count) == 0 {
+		return
+	}
+
+	s := &b.signing
+	fmt.Printf("\n✍️  Remote Signing (included in the send latency above):\n")
+	fmt.Printf("  Signatures:         %d\n", atomic.LoadUint64(&s.count))
+	fmt.Printf("  Average Latency:    %v", b.roundLatency(s.Average()))
+	if avgLatency > 0 {
+		fmt.Printf(" (%.0f%% of the average send)", float64(s.Average())/float64(avgLatency)*100)
+	}
+	fmt.Println()
+	fmt.Printf("  P50 Latency:        %v\n", b.roundLatency(s.latencies.Percentile(50)))
+	fmt.Printf("  P95 Latency:        %v\n", b.roundLatency(s.latencies.Percentile(95)))
+	fmt.Printf("  P99 Latency:        %v\n", b.roundLatency(s.latencies.Percentile(99)))
+}
+
+// signingResults fills the remote signing fields of the results
+func (b *Benchmark) signingResults(results *Results) {
+	if b.config.RemoteSignerURL == "" || atomic.LoadUint64(&b.signing.count) == 0 {
+		return
+	}
+
+	s := &b.signing
+	results.RemoteSignatures = atomic.LoadUint64(&s.count)
+	results.AvgSignLatencyMs = s.Average().Milliseconds()
+	results.P50SignLatencyMs = s.latencies.Percentile(50).Milliseconds()
+	results.P95SignLatencyMs = s.latencies.Percentile(95).Milliseconds()
+	results.P99SignLatencyMs = s.latencies.Percentile(99).Milliseconds()
+}
@@ -0,0 +1,216 @@
+package internal
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+	"github.com/unicornultrafoundation/go-u2u/core/types"
+)
+
+// The replay workload sends recorded traffic from workload_file, a CSV with one transaction
+// per row: senderIndex,recipientAddress,valueWei[,gasLimit]. Each account replays its own rows
+// in file order, shared by its workers, and starts over when it reaches the end. An empty
+// gasLimit uses gas_limit. A header row and lines starting with # are skipped.
+
+// Column checks of a workload_file row, for telling a header from a malformed first row
+var replayColumns = []func(string) bool{isInteger, common.IsHexAddress, isInteger, isInteger}
+
+// replayRow is one recorded transaction
+type replayRow struct {
+	line      int // Line in workload_file, for error messages
+	recipient common.Address
+	value     *big.Int
+	gasLimit  uint64 // 0 = use gas_limit
+}
+
+// replayWorkload is a parsed workload_file
+type replayWorkload struct {
+	rows     map[int][]replayRow // By sender index
+	total    int
+	maxValue *big.Int
+	defaults bool // Some rows use gas_limit
+}
+
+// Parsed workload files by name (the file is needed by validation, balance checks and the builders)
+var replayFiles sync.Map
+
+// replayWorkload loads workload_file, parsing it only once per run
+func (c *Config) replayWorkload() (*replayWorkload, error) {
+	if c.WorkloadFile == "" {
+		return nil, fmt.Errorf("replay workload needs workload_file (CSV of senderIndex,recipientAddress,valueWei,gasLimit)")
+	}
+	if w, ok := replayFiles.Load(c.WorkloadFile); ok {
+		return w.(*replayWorkload), nil
+	}
+	file, err := os.Open(c.WorkloadFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open workload_file: %v", err)
+	}
+	defer file.Close()
+
+	w, err := parseReplayCSV(file)
+	if err != nil {
+		return nil, fmt.Errorf("workload_file %s: %v", c.WorkloadFile, err)
+	}
+	replayFiles.Store(c.WorkloadFile, w)
+	return w, nil
+}
+
+// parseReplayCSV reads and validates the rows of a workload file
+func parseReplayCSV(r io.Reader) (*replayWorkload, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	w := &replayWorkload{rows: make(map[int][]replayRow), maxValue: new(big.Int)}
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		// Optional header
+		if first && isHeaderRow(record, replayColumns) {
+			continue
+		}
+		if len(record) < 3 || len(record) > 4 {
+			return nil, fmt.Errorf("line %d: expected senderIndex,recipientAddress,valueWei[,gasLimit], got %d fields", line, len(record))
+		}
+
+		sender, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil || sender < 0 {
+			return nil, fmt.Errorf("line %d: invalid sender index %q", line, record[0])
+		}
+		to := strings.TrimSpace(record[1])
+		if !common.IsHexAddress(to) {
+			return nil, fmt.Errorf("line %d: invalid recipient address %q", line, to)
+		}
+		value, ok := new(big.Int).SetString(strings.TrimSpace(record[2]), 10)
+		if !ok || value.Sign() < 0 {
+			return nil, fmt.Errorf("line %d: invalid value %q (expected a non-negative integer in wei)", line, record[2])
+		}
+		row := replayRow{line: line, recipient: common.HexToAddress(to), value: value}
+		if len(record) == 4 && strings.TrimSpace(record[3]) != "" {
+			row.gasLimit, err = strconv.ParseUint(strings.TrimSpace(record[3]), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid gas limit %q", line, record[3])
+			}
+			if row.gasLimit < intrinsicTransferGas {
+				return nil, fmt.Errorf("line %d: gas limit %d is below the %d a transfer needs", line, row.gasLimit, intrinsicTransferGas)
+			}
+		} else {
+			w.defaults = true
+		}
+
+		w.rows[sender] = append(w.rows[sender], row)
+		w.total++
+		if value.Cmp(w.maxValue) > 0 {
+			w.maxValue = value
+		}
+	}
+	if w.total == 0 {
+		return nil, fmt.Errorf("no transactions")
+	}
+	return w, nil
+}
+
+// isHeaderRow reports whether the first row of a CSV is a header: none of its fields passes
+// the check of its column. A data row with a typo is reported as an error instead of skipped.
+func isHeaderRow(record []string, columns []func(string) bool) bool {
+	for i, field := range record {
+		if i < len(columns) && columns[i](strings.TrimSpace(field)) {
+			return false
+		}
+	}
+	return true
+}
+
+// isInteger reports whether s is a decimal integer
+func isInteger(s string) bool {
+	_, ok := new(big.Int).SetString(s, 10)
+	return ok
+}
+
+// checkSenders reports rows whose sender index has no account. Sender indices count the
+// loaded keys, so they keep pointing at the same account when underfunded ones are dropped.
+func (w *replayWorkload) checkSenders(accounts []*AccountSender) error {
+	inUse := make(map[int]bool, len(accounts))
+	loaded := 0
+	for _, account := range accounts {
+		inUse[account.keyIndex] = true
+		loaded = max(loaded, account.keyIndex+1)
+	}
+	for sender, rows := range w.rows {
+		if sender >= loaded {
+			return fmt.Errorf("workload_file line %d: sender index %d is out of range (%d accounts loaded)", rows[0].line, sender, loaded)
+		}
+		if !inUse[sender] {
+			return fmt.Errorf("workload_file line %d: sender index %d was skipped as underfunded; fund it or remove its rows", rows[0].line, sender)
+		}
+	}
+	return nil
+}
+
+// replayRows returns the workload_file rows of the account at accountID in b.accounts
+func (b *Benchmark) replayRows(accountID int) []replayRow {
+	return b.replay.rows[b.accounts[accountID].keyIndex]
+}
+
+// replayFactory returns the per-worker builder constructor of the replay workload.
+// Workers of the same account share one cursor, so the account's rows go out in order.
+func (b *Benchmark) replayFactory() (func(accountID int, rng *rand.Rand) TxBuilder, error) {
+	w, err := b.config.replayWorkload()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.checkSenders(b.accounts); err != nil {
+		return nil, err
+	}
+	b.replay = w
+	cursors := make([]uint64, len(b.accounts))
+	return func(accountID int, rng *rand.Rand) TxBuilder {
+		return &replayBuilder{b: b, accountID: accountID, rng: rng, rows: b.replayRows(accountID), cursor: &cursors[accountID]}
+	}, nil
+}
+
+// replayBuilder sends its account's rows of workload_file, looping at the end
+type replayBuilder struct {
+	b         *Benchmark
+	accountID int
+	rng       *rand.Rand
+	rows      []replayRow
+	cursor    *uint64 // Next row, shared by the account's workers (atomic)
+}
+
+func (r *replayBuilder) Build(account *AccountSender, nonce uint64) (*types.Transaction, error) {
+	if len(r.rows) == 0 {
+		return nil, fmt.Errorf("workload_file has no rows for account %d", r.accountID)
+	}
+	row := r.rows[(atomic.AddUint64(r.cursor, 1)-1)%uint64(len(r.rows))]
+	gasLimit := row.gasLimit
+	if gasLimit == 0 {
+		gasLimit = r.b.gasLimitFor(r.rng, intrinsicTransferGas)
+	}
+	return r.b.gasFor(r.accountID).NewTx(
+		account.chainID,
+		nonce,
+		row.recipient,
+		row.value,
+		gasLimit,
+		nil,
+	), nil
+}
@@ -0,0 +1,141 @@
+package internal
+
+import "fmt"
+
+// Results is the benchmark output written to OutputFile.
+// A struct (rather than a map) keeps the JSON field order stable.
+type Results struct {
+	Timestamp           string                 `json:"timestamp"`
+	StopReason          string                 `json:"stop_reason"`
+	Seed                int64                  `json:"seed"`
+	NumCPU              int                    `json:"num_cpu"`    // Logical CPUs of the load generator machine
+	GoMaxProcs          int                    `json:"gomaxprocs"` // Effective GOMAXPROCS of the run
+	RunLabel            string                 `json:"run_label,omitempty"`
+	ChainID             int64                  `json:"chain_id,omitempty"`
+	RPCBatch            bool                   `json:"rpc_batch_supported"`
+	Config              map[string]interface{} `json:"config"`
+	TotalSubmitted      uint64                 `json:"total_submitted"`
+	TotalErrors         uint64                 `json:"total_errors"`
+	RPCAcceptRate       float64                `json:"rpc_accept_rate"`
+	TotalRetries        uint64                 `json:"total_retries"`
+	RetriesPerSuccess   float64                `json:"retries_per_successful_tx"`
+	RateLimitHits       uint64                 `json:"rate_limit_hits"`
+	AlreadyKnownCounted uint64                 `json:"already_known_counted,omitempty"`
+	NonceErrors         uint64                 `json:"nonce_errors"`
+	NonceErrorPercent   float64                `json:"nonce_error_percent"` // Of all send attempts
+	NonceResyncs        uint64                 `json:"nonce_resyncs"`
+	ErrorsByType        map[string]uint64      `json:"errors_by_type"` // Rejected attempts (retries included)
+	AvgSubmittedTPS     float64                `json:"average_submitted_tps"`
+	PeakSubmittedTPS    uint64                 `json:"peak_submitted_tps"`
+	MinSubmittedTPS     uint64                 `json:"min_submitted_tps"`
+	MedianSubmittedTPS  uint64                 `json:"median_submitted_tps"`
+	AvgLatencyMs        int64                  `json:"average_latency_ms"`
+	P50LatencyMs        int64                  `json:"p50_latency_ms"`
+	P95LatencyMs        int64                  `json:"p95_latency_ms"`
+	P99LatencyMs        int64                  `json:"p99_latency_ms"`
+
+	// Remote signing overhead, part of the send latency (only with remote_signer_url)
+	RemoteSignatures uint64 `json:"remote_signatures,omitempty"`
+	AvgSignLatencyMs int64  `json:"average_sign_latency_ms,omitempty"`
+	P50SignLatencyMs int64  `json:"p50_sign_latency_ms,omitempty"`
+	P95SignLatencyMs int64  `json:"p95_sign_latency_ms,omitempty"`
+	P99SignLatencyMs int64  `json:"p99_sign_latency_ms,omitempty"`
+
+	EstimatedSpendWei   string    `json:"estimated_spend_wei"`
+	TimeToLimitSeconds  float64   `json:"time_to_limit_seconds,omitempty"`
+	FastestSend         *TxSample `json:"fastest_send,omitempty"`
+	SlowestSend         *TxSample `json:"slowest_send,omitempty"`
+	ColdStartLatencyMs  int64     `json:"cold_start_latency_ms"`
+	ColdStartTPSPercent float64   `json:"cold_start_tps_percent"`
+	SubmittedTPSHistory []uint64  `json:"submitted_tps_history"`
+
+	// Primary result: average submitted or confirmed TPS (headline_metric), and the min_tps gate
+	HeadlineMetric string  `json:"headline_metric"`
+	HeadlineTPS    float64 `json:"headline_tps"`
+	MinTPS         float64 `json:"min_tps,omitempty"`
+
+	// Rate schedule (only with tps_schedule)
+	ScheduledTPSHistory     []float64 `json:"scheduled_tps_history,omitempty"`
+	IntervalsBehindSchedule int       `json:"intervals_behind_schedule,omitempty"`
+
+	// Inclusion tracking (only with track_confirmations)
+	TotalConfirmed         uint64    `json:"total_confirmed,omitempty"`
+	ConfirmationDepth      uint64    `json:"confirmation_depth,omitempty"`
+	TotalIncluded          uint64    `json:"total_included,omitempty"`
+	TotalReorged           uint64    `json:"total_reorged,omitempty"`
+	AvgConfirmedTPS        float64   `json:"average_confirmed_tps,omitempty"`
+	MaxInflightBacklog     uint64    `json:"max_inflight_backlog,omitempty"`
+	FastestConfirmation    *TxSample `json:"fastest_confirmation,omitempty"`
+	SlowestConfirmation    *TxSample `json:"slowest_confirmation,omitempty"`
+	InflightBacklogHistory []uint64  `json:"inflight_backlog_history,omitempty"`
+	StableConfirmedTPS     float64   `json:"stable_confirmed_tps,omitempty"`     // Mean of the stable intervals (stop_on_stability)
+	StabilizedAfterSeconds float64   `json:"stabilized_after_seconds,omitempty"` // Time into the window at which they were
+	TotalFinalized         uint64    `json:"total_finalized,omitempty"`
+	P50FinalityMs          int64     `json:"p50_finality_ms,omitempty"`
+	P95FinalityMs          int64     `json:"p95_finality_ms,omitempty"`
+	P99FinalityMs          int64     `json:"p99_finality_ms,omitempty"`
+	TotalReverted          uint64    `json:"total_reverted,omitempty"`
+	RevertRate             float64   `json:"revert_rate,omitempty"`
+
+	// Mempool drain phase (only with drain_timeout_seconds)
+	DrainSeconds          float64  `json:"drain_seconds,omitempty"`
+	DrainConfirmed        uint64   `json:"drain_confirmed,omitempty"`
+	DrainConfirmedHistory []uint64 `json:"drain_confirmed_history,omitempty"`
+	FinalInclusionRate    float64  `json:"final_inclusion_rate,omitempty"`
+
+	// Account activation before the run (only with activate_accounts)
+	ActivationSent      int `json:"activation_sent,omitempty"`
+	ActivationConfirmed int `json:"activation_confirmed,omitempty"`
+
+	// Chain height at the start and end of the send window
+	StartBlock        uint64  `json:"start_block,omitempty"`
+	EndBlock          uint64  `json:"end_block,omitempty"`
+	BlocksProduced    uint64  `json:"blocks_produced,omitempty"`
+	ConfirmedPerBlock float64 `json:"confirmed_per_block,omitempty"`
+
+	// HTTP connection pool (peak concurrent requests against max_connections)
+	PeakInflightRequests uint64 `json:"peak_inflight_requests,omitempty"`
+	MaxConnections       int    `json:"max_connections,omitempty"`
+
+	// Transport-level retries and circuit breaking (only with rpc_retry_attempts or circuit_breaker_threshold)
+	Transport *TransportStats `json:"transport,omitempty"`
+
+	// Load generator runtime (only with debug_runtime)
+	PeakGoroutines uint64  `json:"peak_goroutines,omitempty"`
+	MaxHeapMB      float64 `json:"max_heap_mb,omitempty"`
+	MaxGCPauseMs   float64 `json:"max_gc_pause_ms,omitempty"`
+
+	// Per-endpoint account assignment and outcome (only with rpc_urls)
+	Endpoints []EndpointStats `json:"endpoints,omitempty"`
+
+	// Cross-node propagation of sampled sends (track_propagation only)
+	Propagation *PropagationStats `json:"propagation,omitempty"`
+
+	// Block gas utilization against its target (only with target_block_utilization)
+	BlockUtilization *UtilizationStats `json:"block_utilization,omitempty"`
+
+	// Wall-clock time per phase up to the report (only with trace_phases)
+	Phases []PhaseTiming `json:"phases,omitempty"`
+
+	// Per-account balance changes over the run (only with balance_delta_report)
+	BalanceDeltas *BalanceDeltaReport `json:"balance_deltas,omitempty"`
+
+	ErrorSamples    []ErrorSample `json:"error_samples,omitempty"`
+	UnsampledErrors uint64        `json:"unsampled_errors,omitempty"`
+
+	UniqueSenders    int                      `json:"unique_senders"`
+	UniqueRecipients int                      `json:"unique_recipients"`
+	Concurrency      ConcurrencyStats         `json:"concurrency"`
+	SkippedAccounts  int                      `json:"skipped_accounts,omitempty"`
+	FeeGroups        []map[string]interface{} `json:"fee_groups,omitempty"`
+	TxTypes          []TxTypeResult           `json:"tx_types,omitempty"` // Per-type breakdown of a workload_mix
+	AccountStats     []map[string]interface{} `json:"account_statistics"`
+	Diagnostics      []string                 `json:"diagnostics"`
+}
+
+// SummaryLine formats the headline numbers as a single line of key=value pairs for scripts
+func (r *Results) SummaryLine() string {
+	return fmt.Sprintf("sent=%d avg_tps=%.2f peak_tps=%d errors=%d accept_rate=%.2f avg_latency_ms=%d p95_latency_ms=%d headline=%s headline_tps=%.2f",
+		r.TotalSubmitted, r.AvgSubmittedTPS, r.PeakSubmittedTPS, r.TotalErrors,
+		r.RPCAcceptRate, r.AvgLatencyMs, r.P95LatencyMs, r.HeadlineMetric, r.HeadlineTPS)
+}
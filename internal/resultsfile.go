@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/gob"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Results files ending in .bin are written with encoding/gob instead of indented JSON.
+// Soak runs collect millions of interval samples; gob is a fraction of the size and much
+// faster to write and read back.
+const binaryResultsExt = ".bin"
+
+func init() {
+	// Concrete types stored in the interface{} maps of Results (basic types are pre-registered)
+	gob.Register(map[string]float64{})
+	gob.Register([]TPSPoint{})
+}
+
+// isBinaryResults reports whether filename selects the gob format
+func isBinaryResults(filename string) bool {
+	return strings.EqualFold(filepath.Ext(filename), binaryResultsExt)
+}
+
+// writeResultsFile writes v as gob (.bin) or indented JSON (anything else)
+func writeResultsFile(filename string, v interface{}) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(file)
+
+	if isBinaryResults(filename) {
+		err = gob.NewEncoder(w).Encode(v)
+	} else {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(v)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Save writes the results to filename, as gob for a .bin extension and JSON otherwise
+func (r *Results) Save(filename string) error {
+	return writeResultsFile(filename, r)
+}
+
+// LoadResults reads a results file written by Save, detecting the format by extension
+func LoadResults(filename string) (*Results, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var results Results
+	r := bufio.NewReader(file)
+	if isBinaryResults(filename) {
+		err = gob.NewDecoder(r).Decode(&results)
+	} else {
+		err = json.NewDecoder(r).Decode(&results)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
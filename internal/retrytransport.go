@@ -0,0 +1,297 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/unicornultrafoundation/go-u2u/ethclient"
+)
+
+// RetryPolicy configures the retrying transport of CreateOptimizedClient
+type RetryPolicy struct {
+	Attempts         int           // Tries per request, including the first (1 = no retries)
+	Backoff          time.Duration // Base delay, doubled per retry and jittered
+	BreakerThreshold int           // Consecutive failures that open an endpoint's circuit (0 = no breaker)
+	BreakerCooldown  time.Duration // How long an open circuit rejects requests before letting one through
+}
+
+// RetryPolicy returns the transport retry settings, or nil when neither retries nor the breaker are enabled
+func (c *Config) RetryPolicy() *RetryPolicy {
+	if c.RPCRetryAttempts <= 1 && c.CircuitBreakerThreshold <= 0 {
+		return nil
+	}
+	policy := &RetryPolicy{
+		Attempts:         c.RPCRetryAttempts,
+		Backoff:          time.Duration(c.RPCRetryBackoff) * time.Millisecond,
+		BreakerThreshold: c.CircuitBreakerThreshold,
+		BreakerCooldown:  time.Duration(c.CircuitBreakerCooldown) * time.Second,
+	}
+	if policy.Attempts < 1 {
+		policy.Attempts = 1
+	}
+	if policy.Backoff <= 0 {
+		policy.Backoff = 50 * time.Millisecond
+	}
+	if policy.BreakerCooldown <= 0 {
+		policy.BreakerCooldown = 5 * time.Second
+	}
+	return policy
+}
+
+// errCircuitOpen is returned without contacting an endpoint whose circuit is open
+var errCircuitOpen = errors.New("circuit breaker open: endpoint is failing consistently")
+
+// circuit tracks consecutive failures of one endpoint (guarded by retryTransport.mu)
+type circuit struct {
+	failures  int
+	openUntil time.Time
+}
+
+// retryTransport retries transient transport failures (connection errors and HTTP 5xx)
+// with jittered backoff, below the worker's nonce and application retries. Only reads
+// are retried: a send that timed out may have reached the node, and resending it would
+// come back as "already known" and count as a failed send.
+type retryTransport struct {
+	next   http.RoundTripper
+	policy RetryPolicy
+
+	mu       sync.Mutex
+	circuits map[string]*circuit // By endpoint host
+
+	// Counters (atomic)
+	retries   uint64 // Extra attempts made
+	recovered uint64 // Requests that succeeded after at least one retry
+	exhausted uint64 // Requests that still failed after the last attempt
+	opened    uint64 // Times a circuit opened
+	rejected  uint64 // Requests refused while a circuit was open
+}
+
+func newRetryTransport(next http.RoundTripper, policy RetryPolicy) *retryTransport {
+	return &retryTransport{next: next, policy: policy, circuits: make(map[string]*circuit)}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if !t.allow(host) {
+		atomic.AddUint64(&t.rejected, 1)
+		return nil, errCircuitOpen
+	}
+
+	// Buffer the body so every attempt can send it again
+	var body []byte
+	if req.Body != nil && t.policy.Attempts > 1 {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	attempts := t.policy.Attempts
+	if isSendRequest(body) {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		try := req
+		if body != nil {
+			try = req.Clone(req.Context())
+			try.Body = io.NopCloser(bytes.NewReader(body))
+			try.ContentLength = int64(len(body))
+		}
+
+		resp, err := t.next.RoundTrip(try)
+		failed := err != nil || resp.StatusCode >= 500
+		t.record(host, !failed)
+		if !failed {
+			if attempt > 1 {
+				atomic.AddUint64(&t.recovered, 1)
+			}
+			return resp, nil
+		}
+		if attempt >= attempts || req.Context().Err() != nil || !t.allow(host) {
+			if attempt > 1 {
+				atomic.AddUint64(&t.exhausted, 1)
+			}
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		atomic.AddUint64(&t.retries, 1)
+
+		wait := jitteredBackoff(t.policy.Backoff, 0, attempt, nil)
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// Methods that submit a transaction; the transport never repeats them
+var sendMethods = map[string]bool{
+	"eth_sendRawTransaction": true,
+	"eth_sendTransaction":    true,
+}
+
+// isSendRequest reports whether a JSON-RPC request body (single call or batch) submits a transaction
+func isSendRequest(body []byte) bool {
+	type call struct {
+		Method string `json:"method"`
+	}
+	var single call
+	if err := json.Unmarshal(body, &single); err == nil {
+		return sendMethods[single.Method]
+	}
+	var batch []call
+	if err := json.Unmarshal(body, &batch); err == nil {
+		for _, c := range batch {
+			if sendMethods[c.Method] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allow reports whether requests may go to host. After the cooldown an open circuit lets
+// requests through again; the next failure reopens it straight away.
+func (t *retryTransport) allow(host string) bool {
+	if t.policy.BreakerThreshold <= 0 {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	c := t.circuits[host]
+	return c == nil || !time.Now().Before(c.openUntil)
+}
+
+// record updates the host's circuit with the outcome of one attempt
+func (t *retryTransport) record(host string, ok bool) {
+	if t.policy.BreakerThreshold <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	c := t.circuits[host]
+	if c == nil {
+		c = &circuit{}
+		t.circuits[host] = c
+	}
+	if ok {
+		c.failures = 0
+		return
+	}
+	c.failures++
+	if c.failures >= t.policy.BreakerThreshold && !time.Now().Before(c.openUntil) {
+		c.openUntil = time.Now().Add(t.policy.BreakerCooldown)
+		atomic.AddUint64(&t.opened, 1)
+		fmt.Printf("⚠️  Circuit opened for %s after %d consecutive failures; pausing requests for %v\n",
+			host, c.failures, t.policy.BreakerCooldown)
+	}
+}
+
+// TransportStats summarises transport-level retries and circuit breaking
+type TransportStats struct {
+	Retries          uint64 `json:"retries"`          // Extra attempts made
+	RecoveredByRetry uint64 `json:"recovered"`        // Requests that succeeded after a retry
+	RetriesExhausted uint64 `json:"exhausted"`        // Requests that failed on every attempt
+	CircuitOpened    uint64 `json:"circuit_opened"`   // Times an endpoint's circuit opened
+	CircuitRejected  uint64 `json:"circuit_rejected"` // Requests refused while open
+}
+
+// Stats returns the counters since creation or the last Reset
+func (t *retryTransport) Stats() TransportStats {
+	return TransportStats{
+		Retries:          atomic.LoadUint64(&t.retries),
+		RecoveredByRetry: atomic.LoadUint64(&t.recovered),
+		RetriesExhausted: atomic.LoadUint64(&t.exhausted),
+		CircuitOpened:    atomic.LoadUint64(&t.opened),
+		CircuitRejected:  atomic.LoadUint64(&t.rejected),
+	}
+}
+
+// Reset zeroes the counters (end of warmup); circuit state is kept
+func (t *retryTransport) Reset() {
+	for _, counter := range []*uint64{&t.retries, &t.recovered, &t.exhausted, &t.opened, &t.rejected} {
+		atomic.StoreUint64(counter, 0)
+	}
+}
+
+// Retrying transports of clients made by CreateOptimizedClient (*ethclient.Client → *retryTransport)
+var retryTransports sync.Map
+
+// retryTransportFor returns the retrying transport of a client, or nil without a retry policy
+func retryTransportFor(client *ethclient.Client) *retryTransport {
+	if t, ok := retryTransports.Load(client); ok {
+		return t.(*retryTransport)
+	}
+	return nil
+}
+
+// transportGroup is the retrying transports of every client the run sends through
+// (rpc_url plus the rpc_urls clients of PinEndpoints), reported as one
+type transportGroup []*retryTransport
+
+// retryTransportsFor collects the retrying transports of client and the accounts' clients (nil without a retry policy)
+func retryTransportsFor(client *ethclient.Client, accounts []*AccountSender) transportGroup {
+	var group transportGroup
+	seen := make(map[*retryTransport]bool)
+	add := func(client *ethclient.Client) {
+		if t := retryTransportFor(client); t != nil && !seen[t] {
+			seen[t] = true
+			group = append(group, t)
+		}
+	}
+	add(client)
+	for _, account := range accounts {
+		add(account.client)
+	}
+	return group
+}
+
+// Stats sums the counters of the group
+func (g transportGroup) Stats() TransportStats {
+	var total TransportStats
+	for _, t := range g {
+		stats := t.Stats()
+		total.Retries += stats.Retries
+		total.RecoveredByRetry += stats.RecoveredByRetry
+		total.RetriesExhausted += stats.RetriesExhausted
+		total.CircuitOpened += stats.CircuitOpened
+		total.CircuitRejected += stats.CircuitRejected
+	}
+	return total
+}
+
+// Reset zeroes the counters of the group
+func (g transportGroup) Reset() {
+	for _, t := range g {
+		t.Reset()
+	}
+}
+
+// printTransportReport shows what the retrying transport absorbed
+func (b *Benchmark) printTransportReport() {
+	if b.transport == nil {
+		return
+	}
+	stats := b.transport.Stats()
+	fmt.Printf("\n🔁 RPC Transport:\n")
+	fmt.Printf("  Retries:            %d (%d requests recovered, %d failed on every attempt)\n",
+		stats.Retries, stats.RecoveredByRetry, stats.RetriesExhausted)
+	if b.transport[0].policy.BreakerThreshold > 0 {
+		fmt.Printf("  Circuit Breaker:    opened %d times, %d requests rejected\n", stats.CircuitOpened, stats.CircuitRejected)
+	}
+}
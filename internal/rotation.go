@@ -0,0 +1,159 @@
+package internal
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// With max_workers below the number of workers the accounts would get, the run starts a pool
+// of max_workers rotating workers instead. Every account_rotation_seconds each worker leaves
+// its account for the least-used one with a free slot, so over the run every account sends
+// roughly the same number of transactions, not just the first max_workers of them.
+
+// accountRotation hands out accounts to the rotating workers
+type accountRotation struct {
+	mu       sync.Mutex
+	b        *Benchmark
+	busy     []int // Workers currently on each account
+	interval time.Duration
+
+	reassignments uint64 // Times a worker moved to a different account (atomic)
+}
+
+// newAccountRotation returns the rotation of the run, or nil when every account gets its own workers
+func (b *Benchmark) newAccountRotation() *accountRotation {
+	if !b.rotating() {
+		return nil
+	}
+	return &accountRotation{b: b, busy: make([]int, len(b.accounts)), interval: b.config.GetAccountRotationInterval()}
+}
+
+// rotating reports whether max_workers caps the pool below the per-account worker count
+func (b *Benchmark) rotating() bool {
+	return b.config.MaxWorkers > 0 && b.config.MaxWorkers < b.accountWorkers()
+}
+
+// activeAccounts returns how many accounts have at least one sender slot
+func (b *Benchmark) activeAccounts() int {
+	active := 0
+	for i := range b.accounts {
+		if b.sendersForAccount(i) > 0 {
+			active++
+		}
+	}
+	return active
+}
+
+// next releases prev (-1 for none) and returns the account with the fewest attempts among
+// those below their sendersForAccount limit. Ties go to the lowest index.
+func (r *accountRotation) next(prev int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if prev >= 0 {
+		r.busy[prev]--
+	}
+
+	best := -1
+	var bestAttempts uint64
+	for i, account := range r.b.accounts {
+		if r.busy[i] >= r.b.sendersForAccount(i) {
+			continue
+		}
+		attempts := atomic.LoadUint64(&account.sent) + atomic.LoadUint64(&account.errors)
+		if best < 0 || attempts < bestAttempts {
+			best, bestAttempts = i, attempts
+		}
+	}
+	if best < 0 {
+		best = prev // (max_workers is below the total slots, so this does not happen)
+	}
+	r.busy[best]++
+	if prev >= 0 && best != prev {
+		atomic.AddUint64(&r.reassignments, 1)
+	}
+	return best
+}
+
+// release frees the account of a stopping worker
+func (r *accountRotation) release(id int) {
+	r.mu.Lock()
+	r.busy[id]--
+	r.mu.Unlock()
+}
+
+// rotatingWorker sends from one account at a time, moving on every rotation interval
+func (b *Benchmark) rotatingWorker(worker int, seed int64) {
+	defer b.wg.Done()
+	atomic.AddInt64(&b.runningWorkers, 1)
+	defer atomic.AddInt64(&b.runningWorkers, -1)
+
+	rng := rand.New(rand.NewSource(seed))
+	maxRestarts := b.config.GetMaxWorkerRestarts()
+	restarts := 0
+	id := -1
+	for {
+		id = b.rotation.next(id)
+		if b.runSender(id, b.accounts[id], rng, time.After(b.rotation.interval)) {
+			if maxRestarts >= 0 && restarts >= maxRestarts {
+				fmt.Printf("❌ Rotating worker %d stopped after %d restarts\n", worker, restarts)
+				b.rotation.release(id)
+				return
+			}
+			restarts++
+			time.Sleep(workerRestartDelay)
+		}
+		select {
+		case <-b.stopChan:
+			b.rotation.release(id)
+			return
+		default:
+		}
+		if b.limitReached() {
+			b.rotation.release(id)
+			return
+		}
+	}
+}
+
+// RotationStats shows how evenly a rotating pool spread the sends over the accounts
+type RotationStats struct {
+	Workers         int     `json:"workers"`
+	Accounts        int     `json:"accounts"` // Accounts the pool rotated over
+	IntervalSeconds float64 `json:"interval_seconds"`
+	Reassignments   uint64  `json:"reassignments"`
+	MinSent         uint64  `json:"min_sent"` // Fewest transactions sent by one of those accounts
+	MaxSent         uint64  `json:"max_sent"`
+	UnusedAccounts  int     `json:"unused_accounts"` // Accounts that sent nothing
+}
+
+// rotationStats summarises the rotation (nil without max_workers)
+func (b *Benchmark) rotationStats() *RotationStats {
+	if b.rotation == nil {
+		return nil
+	}
+	stats := &RotationStats{
+		Workers:         b.totalWorkers(),
+		IntervalSeconds: b.rotation.interval.Seconds(),
+		Reassignments:   atomic.LoadUint64(&b.rotation.reassignments),
+	}
+	for i, account := range b.accounts {
+		if b.sendersForAccount(i) == 0 {
+			continue
+		}
+		sent := atomic.LoadUint64(&account.sent)
+		if stats.Accounts == 0 || sent < stats.MinSent {
+			stats.MinSent = sent
+		}
+		if sent > stats.MaxSent {
+			stats.MaxSent = sent
+		}
+		if sent == 0 {
+			stats.UnusedAccounts++
+		}
+		stats.Accounts++
+	}
+	return stats
+}
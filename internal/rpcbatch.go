@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/unicornultrafoundation/go-u2u/rpc"
+)
+
+// probeBatchSupport sends a tiny JSON-RPC batch (eth_chainId + eth_blockNumber) and
+// returns nil if the endpoint answered both calls. Some endpoints and proxies reject
+// batches outright, others answer with an error per element; both count as unsupported.
+func probeBatchSupport(ctx context.Context, rpcURL string, tlsConfig *tls.Config) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	client, err := DialRPC(ctx, rpcURL, tlsConfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %v", err)
+	}
+	defer client.Close()
+
+	var chainID, blockNumber string
+	batch := []rpc.BatchElem{
+		{Method: "eth_chainId", Result: &chainID},
+		{Method: "eth_blockNumber", Result: &blockNumber},
+	}
+	if err := client.BatchCallContext(ctx, batch); err != nil {
+		return err
+	}
+	for _, elem := range batch {
+		if elem.Error != nil {
+			return fmt.Errorf("%s in batch: %v", elem.Method, elem.Error)
+		}
+	}
+	if chainID == "" || blockNumber == "" {
+		return fmt.Errorf("empty batch response")
+	}
+	return nil
+}
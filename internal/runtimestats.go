@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// How often the runtime monitor prints a line (it samples every second)
+const runtimeLogInterval = 10 * time.Second
+
+// GOMAXPROCS at startup, restored for runs without gomaxprocs (e.g. later -compare-configs runs)
+var defaultGoMaxProcs = runtime.GOMAXPROCS(0)
+
+// ApplyGoMaxProcs sets GOMAXPROCS from gomaxprocs (or back to the default) and returns the effective
+// value. Call it before connecting, so account setup runs with the same setting as the send window.
+func ApplyGoMaxProcs(config *Config) int {
+	procs := defaultGoMaxProcs
+	if config.GoMaxProcs > 0 {
+		procs = config.GoMaxProcs
+	}
+	runtime.GOMAXPROCS(procs)
+	return runtime.GOMAXPROCS(0)
+}
+
+// runtimeStats holds peaks observed by the runtime monitor (atomic)
+type runtimeStats struct {
+	peakGoroutines uint64
+	peakHeapBytes  uint64
+	maxGCPauseNs   uint64
+}
+
+// runtimeMonitor samples goroutines, heap and GC pauses of the load generator itself
+// so a saturated client can be told apart from a slow chain.
+func (b *Benchmark) runtimeMonitor() {
+	sampleTicker := time.NewTicker(time.Second)
+	defer sampleTicker.Stop()
+	logTicker := time.NewTicker(runtimeLogInterval)
+	defer logTicker.Stop()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	lastNumGC := mem.NumGC
+
+	for {
+		select {
+		case <-b.stopMetricsChan:
+			return
+		case <-sampleTicker.C:
+			runtime.ReadMemStats(&mem)
+			lastNumGC = b.sampleRuntime(&mem, lastNumGC)
+		case <-logTicker.C:
+			fmt.Printf("🧠 Runtime [%s]: %d goroutines, heap %.1f MB, %d GCs, total GC pause %v\n",
+				formatDuration(time.Since(b.startTime())), runtime.NumGoroutine(),
+				float64(mem.HeapAlloc)/(1024*1024), mem.NumGC,
+				time.Duration(mem.PauseTotalNs).Round(time.Microsecond))
+		}
+	}
+}
+
+// sampleRuntime updates the peaks from one MemStats reading and returns its GC count
+func (b *Benchmark) sampleRuntime(mem *runtime.MemStats, lastNumGC uint32) uint32 {
+	storeMax(&b.loadGen.peakGoroutines, uint64(runtime.NumGoroutine()))
+	storeMax(&b.loadGen.peakHeapBytes, mem.HeapAlloc)
+
+	// PauseNs is a circular buffer of the most recent 256 pauses
+	newGCs := mem.NumGC - lastNumGC
+	if newGCs > uint32(len(mem.PauseNs)) {
+		newGCs = uint32(len(mem.PauseNs))
+	}
+	for i := uint32(0); i < newGCs; i++ {
+		pause := mem.PauseNs[(mem.NumGC-i+255)%uint32(len(mem.PauseNs))]
+		storeMax(&b.loadGen.maxGCPauseNs, pause)
+	}
+	return mem.NumGC
+}
+
+// printRuntimeReport prints the load generator section of the final report
+func (b *Benchmark) printRuntimeReport() {
+	if !b.config.DebugRuntime {
+		return
+	}
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	fmt.Printf("\n🧠 Load Generator Runtime:\n")
+	fmt.Printf("  Peak Goroutines:    %d\n", atomic.LoadUint64(&b.loadGen.peakGoroutines))
+	fmt.Printf("  Max Heap:           %.1f MB\n", float64(atomic.LoadUint64(&b.loadGen.peakHeapBytes))/(1024*1024))
+	fmt.Printf("  GC Runs:            %d\n", mem.NumGC)
+	fmt.Printf("  Total GC Pause:     %v\n", time.Duration(mem.PauseTotalNs).Round(time.Microsecond))
+	fmt.Printf("  Max GC Pause:       %v\n", time.Duration(atomic.LoadUint64(&b.loadGen.maxGCPauseNs)).Round(time.Microsecond))
+	fmt.Printf("  CPUs:               %d (GOMAXPROCS %d)\n", runtime.NumCPU(), runtime.GOMAXPROCS(0))
+}
+
+// storeMax atomically raises *addr to value if value is larger
+func storeMax(addr *uint64, value uint64) {
+	for {
+		current := atomic.LoadUint64(addr)
+		if value <= current || atomic.CompareAndSwapUint64(addr, current, value) {
+			return
+		}
+	}
+}
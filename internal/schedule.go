@@ -0,0 +1,215 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// TPSPoint is one point of tps_schedule: the target rate At seconds into the measured window
+type TPSPoint struct {
+	At  float64 `json:"at"`
+	TPS float64 `json:"tps"`
+}
+
+// tpsSchedule is a piecewise-linear target rate. Before the first point the first rate
+// applies, after the last point the last rate is held.
+type tpsSchedule []TPSPoint
+
+// newTPSSchedule validates the configured points (nil when no schedule is set)
+func newTPSSchedule(points []TPSPoint) (tpsSchedule, error) {
+	for i, p := range points {
+		if p.At < 0 || p.TPS < 0 {
+			return nil, fmt.Errorf("tps_schedule[%d]: at and tps must not be negative", i)
+		}
+		if i > 0 && p.At <= points[i-1].At {
+			return nil, fmt.Errorf("tps_schedule[%d]: at must increase (%g after %g)", i, p.At, points[i-1].At)
+		}
+	}
+	if len(points) == 0 {
+		return nil, nil
+	}
+	return tpsSchedule(points), nil
+}
+
+// Rate returns the target TPS t seconds into the window
+func (s tpsSchedule) Rate(t float64) float64 {
+	if t <= s[0].At {
+		return s[0].TPS
+	}
+	for i := 1; i < len(s); i++ {
+		if t <= s[i].At {
+			a, b := s[i-1], s[i]
+			return a.TPS + (b.TPS-a.TPS)*(t-a.At)/(b.At-a.At)
+		}
+	}
+	return s[len(s)-1].TPS
+}
+
+// Total returns how many transactions the schedule calls for in the first t seconds
+func (s tpsSchedule) Total(t float64) float64 {
+	total := 0.0
+	prevAt, prevRate := 0.0, s.Rate(0)
+	for _, p := range s {
+		if p.At <= prevAt {
+			continue
+		}
+		if p.At >= t {
+			break
+		}
+		total += (prevRate + p.TPS) / 2 * (p.At - prevAt)
+		prevAt, prevRate = p.At, p.TPS
+	}
+	if t > prevAt {
+		total += (prevRate + s.Rate(t)) / 2 * (t - prevAt)
+	}
+	return total
+}
+
+// rateSource is the target rate a ratePacer follows: tps_schedule or the utilization controller
+type rateSource interface {
+	Rate(t float64) float64  // Target TPS t seconds into the window
+	Total(t float64) float64 // Sends due in the first t seconds
+}
+
+// averageRate returns the mean target TPS of source between from and to seconds into the window
+func averageRate(source rateSource, from, to float64) float64 {
+	if to <= from {
+		return source.Rate(from)
+	}
+	return (source.Total(to) - source.Total(from)) / (to - from)
+}
+
+// ratePacer spaces sends across all workers so the submission rate follows the schedule.
+// A worker that falls behind may catch up by at most one second's worth of sends.
+type ratePacer struct {
+	schedule rateSource
+
+	mu     sync.Mutex
+	start  time.Time
+	issued float64 // Sends released since start
+}
+
+func newRatePacer(schedule rateSource) *ratePacer {
+	return &ratePacer{schedule: schedule, start: time.Now()}
+}
+
+// Reset restarts the schedule from its first point (at the start of the measured window)
+func (p *ratePacer) Reset() {
+	p.mu.Lock()
+	p.start = time.Now()
+	p.issued = 0
+	p.mu.Unlock()
+}
+
+// Wait blocks until the schedule allows one more send. Returns false if stop closes first.
+func (p *ratePacer) Wait(stop <-chan struct{}) bool {
+	p.mu.Lock()
+	elapsed := time.Since(p.start).Seconds()
+	due := p.schedule.Total(elapsed)
+	if burst := math.Max(1, p.schedule.Rate(elapsed)); p.issued < due-burst {
+		p.issued = due - burst
+	}
+	p.issued++
+	slot := p.issued
+	start := p.start
+	p.mu.Unlock()
+
+	for {
+		elapsed := time.Since(start).Seconds()
+		missing := slot - p.schedule.Total(elapsed)
+		if missing <= 0 {
+			return true
+		}
+
+		// Sleep until the slot is roughly due, re-checking at least every 100ms as the rate changes
+		wait := 100 * time.Millisecond
+		if rate := p.schedule.Rate(elapsed); rate > 0 {
+			if d := time.Duration(missing / rate * float64(time.Second)); d < wait {
+				wait = d
+			}
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-stop:
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+
+		// A reset (end of warmup) invalidates the slot; take a new one
+		p.mu.Lock()
+		reset := !p.start.Equal(start)
+		p.mu.Unlock()
+		if reset {
+			return p.Wait(stop)
+		}
+	}
+}
+
+// scheduledAt returns the target TPS averaged over the report interval that ends elapsed into the window
+func (b *Benchmark) scheduledAt(elapsed time.Duration) float64 {
+	interval := float64(b.config.ReportInterval)
+	to := elapsed.Seconds()
+	return averageRate(b.pacer.schedule, math.Max(0, to-interval), to)
+}
+
+// printScheduleReport overlays the achieved rate on the scheduled rate per interval
+// (not with the utilization controller, whose rate follows the chain instead of a plan)
+func (b *Benchmark) printScheduleReport() {
+	if b.pacer == nil || b.utilization != nil || len(b.scheduledHistory) == 0 {
+		return
+	}
+	interval := float64(b.config.ReportInterval)
+
+	fmt.Printf("\n📈 TPS Schedule (achieved vs. scheduled per interval):\n")
+	fmt.Printf("  %-10s | %-9s | %-9s |\n", "Time", "Scheduled", "Achieved")
+	firstBehind := -1
+	for i, scheduled := range b.scheduledHistory {
+		achieved := b.achievedAt(i)
+		mark := ""
+		if behindSchedule(achieved, scheduled) {
+			mark = fmt.Sprintf(" ⚠️  %.0f%% behind", (1-achieved/scheduled)*100)
+			if firstBehind < 0 {
+				firstBehind = i
+			}
+		}
+		fmt.Printf("  %-10s | %-9.1f | %-9.1f |%s\n", formatDuration(intervalEnd(i, interval)), scheduled, achieved, mark)
+	}
+	if firstBehind < 0 {
+		fmt.Printf("  Kept up with the schedule in every interval\n")
+	} else {
+		fmt.Printf("  Behind Schedule:    %d of %d intervals (>10%% below), first at %s\n",
+			b.intervalsBehindSchedule(), len(b.scheduledHistory), formatDuration(intervalEnd(firstBehind, interval)))
+	}
+}
+
+// achievedAt returns the achieved rate in report interval i
+func (b *Benchmark) achievedAt(i int) float64 {
+	if i >= len(b.tpsHistory) {
+		return 0
+	}
+	return float64(b.tpsHistory[i]) / float64(b.config.ReportInterval)
+}
+
+// behindSchedule reports whether an interval fell more than 10% short of its target
+func behindSchedule(achieved, scheduled float64) bool {
+	return scheduled > 0 && achieved < scheduled*0.9
+}
+
+// intervalsBehindSchedule counts the report intervals that fell behind the schedule
+func (b *Benchmark) intervalsBehindSchedule() int {
+	behind := 0
+	for i, scheduled := range b.scheduledHistory {
+		if behindSchedule(b.achievedAt(i), scheduled) {
+			behind++
+		}
+	}
+	return behind
+}
+
+// intervalEnd returns the time into the window at which report interval i ends
+func intervalEnd(i int, interval float64) time.Duration {
+	return time.Duration(float64(i+1) * interval * float64(time.Second))
+}
@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// How long workers get to return once their in-flight sends have been cancelled
+const shutdownCancelGrace = 2 * time.Second
+
+// stopWorkers signals the sender workers to stop and waits up to shutdown_timeout_seconds
+// for them. Workers still running after that (typically blocked in a send on a hung
+// connection) have their send context cancelled; any that still don't return are left
+// behind and the run goes on to the report without them.
+func (b *Benchmark) stopWorkers() {
+	close(b.stopChan)
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	timeout := b.config.GetShutdownTimeout()
+	select {
+	case <-done:
+		return
+	case <-time.After(timeout):
+	}
+
+	running := atomic.LoadInt64(&b.runningWorkers)
+	atomic.StoreInt64(&b.workersCancelled, running)
+	fmt.Printf("\n⚠️  %d workers still sending after %v; cancelling their in-flight requests\n", running, timeout)
+	b.cancelSends()
+
+	select {
+	case <-done:
+		return
+	case <-time.After(shutdownCancelGrace):
+	}
+	abandoned := atomic.LoadInt64(&b.runningWorkers)
+	atomic.StoreInt64(&b.workersAbandoned, abandoned)
+	fmt.Printf("⚠️  %d workers did not stop; continuing to the report without them\n", abandoned)
+}
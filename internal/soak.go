@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// soakMonitor periodically logs a rolling health summary and requests a stop
+// once the error rate stays above the threshold for too many intervals in a row.
+func (b *Benchmark) soakMonitor() {
+	interval := time.Duration(b.config.SoakHealthInterval) * time.Second
+	if interval <= 0 {
+		interval = 60 * time.Second
+	}
+	maxBadIntervals := b.config.SoakMaxBadIntervals
+	if maxBadIntervals <= 0 {
+		maxBadIntervals = 3
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastSent := atomic.LoadUint64(&b.sentCount)
+	lastErrors := atomic.LoadUint64(&b.errorCount)
+	badIntervals := 0
+	threshold := b.config.GetSoakErrorRateThreshold()
+
+	for {
+		select {
+		case <-b.stopRequested:
+			return
+		case <-ticker.C:
+			sent := atomic.LoadUint64(&b.sentCount)
+			errors := atomic.LoadUint64(&b.errorCount)
+			sentDelta := sent - lastSent
+			errorsDelta := errors - lastErrors
+			lastSent, lastErrors = sent, errors
+
+			errorRate := 0.0
+			if sentDelta+errorsDelta > 0 {
+				errorRate = float64(errorsDelta) / float64(sentDelta+errorsDelta) * 100
+			}
+
+			var mem runtime.MemStats
+			runtime.ReadMemStats(&mem)
+
+			fmt.Printf("🩺 Health [%s]: %.1f TPS, %.2f%% errors, heap %.1f MB, %d goroutines\n",
+				formatDuration(time.Since(b.startTime())),
+				float64(sentDelta)/interval.Seconds(), errorRate,
+				float64(mem.HeapAlloc)/(1024*1024), runtime.NumGoroutine())
+
+			if errorRate > threshold {
+				badIntervals++
+				fmt.Printf("⚠️  Unhealthy interval %d/%d (error rate above %.2f%%)\n",
+					badIntervals, maxBadIntervals, threshold)
+				if badIntervals >= maxBadIntervals {
+					b.requestStop(fmt.Sprintf("error rate above %.2f%% for %d consecutive intervals",
+						threshold, badIntervals))
+					return
+				}
+			} else {
+				badIntervals = 0
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"fmt"
+	"math/big"
+	"sync/atomic"
+)
+
+var weiPerGwei = big.NewInt(1e9)
+
+// spendTracker estimates what the run has cost (value + gas limit × price per
+// submitted transaction) and stops the run once max_spend_u2u is reached.
+// Amounts are kept in gwei so a uint64 covers billions of U2U.
+type spendTracker struct {
+	txCostGwei []uint64 // Estimated cost of one transaction, per fee group
+	limitGwei  uint64   // 0 = no cap
+	spentGwei  uint64   // atomic
+}
+
+// newSpendTracker prices one transaction for every fee group
+func newSpendTracker(config *Config, value *big.Int, feeGroups []*GasSettings) (*spendTracker, error) {
+	tracker := &spendTracker{txCostGwei: make([]uint64, len(feeGroups))}
+
+	if config.MaxSpend != "" {
+		limit, err := ParseAmount(config.MaxSpend, "u2u")
+		if err != nil {
+			return nil, fmt.Errorf("max_spend_u2u: %v", err)
+		}
+		tracker.limitGwei = toGweiCeil(limit)
+	}
+
+	for g, gas := range feeGroups {
+		cost := new(big.Int).Mul(new(big.Int).SetUint64(config.GasLimit), gas.GasPrice)
+		cost.Add(cost, value)
+		tracker.txCostGwei[g] = toGweiCeil(cost)
+	}
+	return tracker, nil
+}
+
+// toGweiCeil converts wei to gwei, rounding up
+func toGweiCeil(wei *big.Int) uint64 {
+	gwei := new(big.Int).Add(wei, new(big.Int).Sub(weiPerGwei, big.NewInt(1)))
+	return gwei.Div(gwei, weiPerGwei).Uint64()
+}
+
+// add records one submitted transaction and reports whether the cap is now reached
+func (t *spendTracker) add(group int) bool {
+	spent := atomic.AddUint64(&t.spentGwei, t.txCostGwei[group])
+	return t.limitGwei > 0 && spent >= t.limitGwei
+}
+
+// spent returns the estimated spend so far in wei
+func (t *spendTracker) spent() *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(atomic.LoadUint64(&t.spentGwei)), weiPerGwei)
+}
+
+// limit returns the cap in wei (nil without a cap)
+func (t *spendTracker) limit() *big.Int {
+	if t.limitGwei == 0 {
+		return nil
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(t.limitGwei), weiPerGwei)
+}
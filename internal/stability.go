@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// stabilityDetector watches the confirmed TPS per report interval (stop_on_stability).
+// The rate is stable once the last stability_intervals intervals all lie within
+// ±stability_band_percent of their mean. Only the metrics reporter goroutine uses it.
+type stabilityDetector struct {
+	band   float64 // Fraction of the mean
+	window int
+	recent []float64
+
+	stableTPS   float64       // Mean of the window that was stable
+	stableAfter time.Duration // Time into the measured window at which it was stable (0 = not yet)
+}
+
+func newStabilityDetector(config *Config) *stabilityDetector {
+	return &stabilityDetector{band: config.GetStabilityBand() / 100, window: config.GetStabilityIntervals()}
+}
+
+// Record adds the confirmed TPS of one interval and reports whether the rate has just become stable
+func (d *stabilityDetector) Record(tps float64, elapsed time.Duration) bool {
+	if d.stableAfter > 0 {
+		return false
+	}
+	d.recent = append(d.recent, tps)
+	if len(d.recent) > d.window {
+		d.recent = d.recent[1:]
+	}
+	if len(d.recent) < d.window {
+		return false
+	}
+
+	mean := 0.0
+	for _, v := range d.recent {
+		mean += v
+	}
+	mean /= float64(len(d.recent))
+	if mean <= 0 {
+		return false
+	}
+	for _, v := range d.recent {
+		if math.Abs(v-mean) > d.band*mean {
+			return false
+		}
+	}
+	d.stableTPS, d.stableAfter = mean, elapsed
+	return true
+}
+
+// reason is the stop reason once the rate is stable
+func (d *stabilityDetector) reason() string {
+	return fmt.Sprintf("confirmed TPS stable within ±%g%% for %d intervals", d.band*100, d.window)
+}
+
+// printStabilityLine adds the steady-state rate to the confirmation metrics
+func (b *Benchmark) printStabilityLine() {
+	d := b.stability
+	if d == nil {
+		return
+	}
+	if d.stableAfter == 0 {
+		fmt.Printf("  Steady State:       not reached (no %d intervals in a row within ±%g%%)\n", d.window, d.band*100)
+		return
+	}
+	fmt.Printf("  Steady State:       %.2f TPS (±%g%% over %d intervals, reached after %v)\n",
+		d.stableTPS, d.band*100, d.window, d.stableAfter.Round(time.Second))
+}
@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/unicornultrafoundation/go-u2u/ethclient"
+)
+
+// Backoff between startup attempts: doubles from startupBackoffBase up to startupBackoffMax
+const (
+	startupBackoffBase = 500 * time.Millisecond
+	startupBackoffMax  = 8 * time.Second
+)
+
+// RetryStartup runs fn up to attempts times with exponential backoff, so a public
+// endpoint that fails the first request now and then does not abort the command.
+// Returns the last error once all attempts have failed.
+func RetryStartup(ctx context.Context, what string, attempts int, fn func(ctx context.Context) error) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	backoff := startupBackoffBase
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+		if attempt >= attempts {
+			break
+		}
+
+		fmt.Printf("⚠️  %s failed (attempt %d/%d): %v; retrying in %v\n", what, attempt, attempts, err, backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		if backoff *= 2; backoff > startupBackoffMax {
+			backoff = startupBackoffMax
+		}
+	}
+	if attempts > 1 {
+		return fmt.Errorf("%v (after %d attempts)", err, attempts)
+	}
+	return err
+}
+
+// FetchChainID asks the node for its chain ID, retrying transient failures
+func FetchChainID(ctx context.Context, client *ethclient.Client, attempts int) (*big.Int, error) {
+	var chainID *big.Int
+	err := RetryStartup(ctx, "Getting the chain ID", attempts, func(ctx context.Context) error {
+		var err error
+		chainID, err = client.ChainID(ctx)
+		return err
+	})
+	return chainID, err
+}
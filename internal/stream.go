@@ -0,0 +1,71 @@
+package internal
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// StreamRecord is one line of the JSON-lines stream, written at every report interval
+type StreamRecord struct {
+	ElapsedSeconds float64           `json:"elapsed_seconds"`
+	Submitted      uint64            `json:"submitted"` // In this interval
+	TotalSubmitted uint64            `json:"total_submitted"`
+	TotalErrors    uint64            `json:"total_errors"`
+	AvgLatencyMs   int64             `json:"avg_latency_ms"` // Of this interval's sends
+	ErrorsByType   map[string]uint64 `json:"errors_by_type"` // Rejected attempts in this interval
+	ScheduledTPS   *float64          `json:"scheduled_tps,omitempty"`
+	Confirmed      *uint64           `json:"confirmed,omitempty"`
+	Backlog        *uint64           `json:"backlog,omitempty"`
+}
+
+// metricsStream writes one JSON object per report interval for live dashboards.
+// Every line is written straight to the file so a tail -f sees it immediately.
+type metricsStream struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+	failed  bool // Set after the first write error; later records are dropped
+
+	lastKinds [len(errorKinds)]uint64
+}
+
+// newMetricsStream creates (or truncates) the stream file
+func newMetricsStream(filename string) (*metricsStream, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsStream{file: file, encoder: json.NewEncoder(file)}, nil
+}
+
+// Write appends one record, filling in the error breakdown since the previous record.
+// Only the first write error is returned.
+func (s *metricsStream) Write(record StreamRecord, kinds [len(errorKinds)]uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failed {
+		return nil
+	}
+
+	var delta [len(errorKinds)]uint64
+	for i := range kinds {
+		if kinds[i] >= s.lastKinds[i] {
+			delta[i] = kinds[i] - s.lastKinds[i]
+		}
+	}
+	s.lastKinds = kinds
+	record.ErrorsByType = errorKindMap(delta)
+	if err := s.encoder.Encode(record); err != nil {
+		s.failed = true
+		return err
+	}
+	return nil
+}
+
+// Close closes the stream file
+func (s *metricsStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
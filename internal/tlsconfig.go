@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/unicornultrafoundation/go-u2u/rpc"
+)
+
+// TLSConfig builds the TLS settings of the RPC transport from tls_ca_cert_file and
+// tls_insecure_skip_verify. Returns nil (Go's default verification) when neither is set.
+func (c *Config) TLSConfig() (*tls.Config, error) {
+	tlsConfig, err := c.tlsSettings()
+	if err != nil || !c.TLSInsecureSkipVerify {
+		return tlsConfig, err
+	}
+	fmt.Println("⚠️  ════════════════════════════════════════════════════════════════")
+	fmt.Println("⚠️  TLS certificate verification is DISABLED (tls_insecure_skip_verify)")
+	fmt.Println("⚠️  Anyone on the network path can impersonate the RPC endpoint.")
+	fmt.Println("⚠️  Only use this against lab nodes; prefer tls_ca_cert_file.")
+	fmt.Println("⚠️  ════════════════════════════════════════════════════════════════")
+	return tlsConfig, nil
+}
+
+// tlsSettings builds the TLS settings without the warning (for dials after TLSConfig was checked)
+func (c *Config) tlsSettings() (*tls.Config, error) {
+	if c.TLSCACertFile == "" && !c.TLSInsecureSkipVerify {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+	if c.TLSCACertFile != "" {
+		pem, err := os.ReadFile(c.TLSCACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls_ca_cert_file: %v", err)
+		}
+		// Trust the extra CA on top of the system roots
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("tls_ca_cert_file %s contains no PEM certificates", c.TLSCACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	tlsConfig.InsecureSkipVerify = c.TLSInsecureSkipVerify
+	return tlsConfig, nil
+}
+
+// DialRPC connects to an RPC endpoint, using tlsConfig for https (nil = default verification).
+// Other schemes (ws, ipc) are dialed as usual.
+func DialRPC(ctx context.Context, url string, tlsConfig *tls.Config) (*rpc.Client, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return rpc.DialContext(ctx, url)
+	}
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	return rpc.DialHTTPWithClient(url, httpClient)
+}
+
+// dialRPC connects to url with the config's TLS settings
+func (c *Config) dialRPC(ctx context.Context, url string) (*rpc.Client, error) {
+	tlsConfig, err := c.tlsSettings()
+	if err != nil {
+		return nil, err
+	}
+	return DialRPC(ctx, url, tlsConfig)
+}
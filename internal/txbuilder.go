@@ -0,0 +1,205 @@
+package internal
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+	"github.com/unicornultrafoundation/go-u2u/core/types"
+)
+
+// TxBuilder constructs the unsigned transaction for one send. The send loop owns
+// nonces, signing, submission and retries; a builder only decides what is sent.
+// Builders are created per worker, so they may keep state (such as the worker's
+// RNG) without locking. Adding a workload means adding a builder here and a case
+// in selectTxBuilder.
+type TxBuilder interface {
+	Build(account *AccountSender, nonce uint64) (*types.Transaction, error)
+}
+
+// recipientReporter is implemented by builders whose transactions pay a benchmark
+// account; the send loop credits that account once the send is accepted.
+type recipientReporter interface {
+	LastRecipient() *AccountSender
+}
+
+// selectTxBuilder sets the per-worker builder constructor for the configured workload (or workload mix)
+func (b *Benchmark) selectTxBuilder() error {
+	if len(b.config.WorkloadMix) > 0 {
+		return b.selectMixedBuilder()
+	}
+	factory, err := b.builderFactory(b.config.Workload)
+	if err != nil {
+		return err
+	}
+	b.newBuilder = factory
+	return nil
+}
+
+// builderFactory returns the per-worker builder constructor of one workload
+func (b *Benchmark) builderFactory(workload string) (func(accountID int, rng *rand.Rand) TxBuilder, error) {
+	switch workload {
+	case WorkloadERC20:
+		token, amount, err := b.config.erc20Params()
+		if err != nil {
+			return nil, err
+		}
+		return func(accountID int, rng *rand.Rand) TxBuilder {
+			return &erc20TransferBuilder{b: b, accountID: accountID, rng: rng, token: token, amount: amount}
+		}, nil
+	case WorkloadDeploy:
+		code, err := b.config.deployCode()
+		if err != nil {
+			return nil, err
+		}
+		return func(accountID int, rng *rand.Rand) TxBuilder {
+			return &deployBuilder{b: b, accountID: accountID, rng: rng, code: code, floor: intrinsicCreateGas(code)}
+		}, nil
+	case WorkloadCall:
+		spec, err := b.config.callSpec()
+		if err != nil {
+			return nil, err
+		}
+		return func(accountID int, rng *rand.Rand) TxBuilder {
+			return &contractCallBuilder{b: b, accountID: accountID, rng: rng, spec: spec}
+		}, nil
+	case WorkloadReplay:
+		return b.replayFactory()
+	default:
+		value, err := b.config.TransferValue()
+		if err != nil {
+			return nil, err
+		}
+		return func(accountID int, rng *rand.Rand) TxBuilder {
+			return &transferBuilder{b: b, accountID: accountID, rng: rng, value: value}
+		}, nil
+	}
+}
+
+// transferBuilder sends transfer_amount_wei to the account picked by the transfer pattern
+type transferBuilder struct {
+	b         *Benchmark
+	accountID int
+	rng       *rand.Rand
+	value     *big.Int
+	recipient *AccountSender
+}
+
+func (t *transferBuilder) Build(account *AccountSender, nonce uint64) (*types.Transaction, error) {
+	t.recipient = t.b.accounts[t.b.recipientFor(t.accountID)]
+	return t.b.gasFor(t.accountID).NewTx(
+		account.chainID,
+		nonce,
+		t.recipient.from,
+		t.value,
+		t.b.gasLimitFor(t.rng, intrinsicTransferGas),
+		nil,
+	), nil
+}
+
+func (t *transferBuilder) LastRecipient() *AccountSender {
+	return t.recipient
+}
+
+// erc20TransferBuilder calls transfer(recipient, erc20_amount) on erc20_token_address.
+// The recipient is picked by the transfer pattern, as for native transfers.
+type erc20TransferBuilder struct {
+	b         *Benchmark
+	accountID int
+	rng       *rand.Rand
+	token     common.Address
+	amount    *big.Int
+	recipient *AccountSender
+}
+
+// Selector of transfer(address,uint256)
+var erc20TransferSelector = []byte{0xa9, 0x05, 0x9c, 0xbb}
+
+func (t *erc20TransferBuilder) Build(account *AccountSender, nonce uint64) (*types.Transaction, error) {
+	t.recipient = t.b.accounts[t.b.recipientFor(t.accountID)]
+
+	data := make([]byte, 0, 4+32+32)
+	data = append(data, erc20TransferSelector...)
+	data = append(data, common.LeftPadBytes(t.recipient.from.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(t.amount.Bytes(), 32)...)
+
+	return t.b.gasFor(t.accountID).NewTx(
+		account.chainID,
+		nonce,
+		t.token,
+		new(big.Int),
+		t.b.gasLimitFor(t.rng, intrinsicTransferGas),
+		data,
+	), nil
+}
+
+func (t *erc20TransferBuilder) LastRecipient() *AccountSender {
+	return t.recipient
+}
+
+// deployBuilder deploys deploy_bytecode as a new contract on every send
+type deployBuilder struct {
+	b         *Benchmark
+	accountID int
+	rng       *rand.Rand
+	code      []byte
+	floor     uint64 // Intrinsic gas of the deployment
+}
+
+func (d *deployBuilder) Build(account *AccountSender, nonce uint64) (*types.Transaction, error) {
+	return d.b.gasFor(d.accountID).NewDeployTx(
+		account.chainID,
+		nonce,
+		new(big.Int),
+		d.b.gasLimitFor(d.rng, d.floor),
+		d.code,
+	), nil
+}
+
+// Init code deploying a contract with empty runtime code: PUSH1 0 DUP1 RETURN
+const defaultDeployBytecode = "0x600080f3"
+
+// intrinsicCreateGas is the gas a contract creation costs before any init code runs:
+// the creation base cost, calldata, and the per-word init code charge
+func intrinsicCreateGas(code []byte) uint64 {
+	gas := uint64(53000)
+	for _, c := range code {
+		if c == 0 {
+			gas += 4
+		} else {
+			gas += 16
+		}
+	}
+	return gas + 2*uint64((len(code)+31)/32)
+}
+
+// erc20Params parses the token address and per-transfer amount of the erc20 workload
+func (c *Config) erc20Params() (common.Address, *big.Int, error) {
+	if !common.IsHexAddress(c.ERC20TokenAddress) {
+		return common.Address{}, nil, fmt.Errorf("erc20 workload needs a valid erc20_token_address, got %q", c.ERC20TokenAddress)
+	}
+
+	amount := big.NewInt(1)
+	if c.ERC20Amount != "" {
+		var ok bool
+		amount, ok = new(big.Int).SetString(c.ERC20Amount, 10)
+		if !ok || amount.Sign() < 0 {
+			return common.Address{}, nil, fmt.Errorf("invalid erc20_amount: %q", c.ERC20Amount)
+		}
+	}
+	return common.HexToAddress(c.ERC20TokenAddress), amount, nil
+}
+
+// deployCode returns the init code of the deploy workload
+func (c *Config) deployCode() ([]byte, error) {
+	hex := c.DeployBytecode
+	if hex == "" {
+		hex = defaultDeployBytecode
+	}
+	code := common.FromHex(hex)
+	if len(code) == 0 {
+		return nil, fmt.Errorf("invalid deploy_bytecode: %q", c.DeployBytecode)
+	}
+	return code, nil
+}
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+)
+
+// TxHashLog records submitted transaction hashes, one per line
+type TxHashLog struct {
+	mu     sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+}
+
+// NewTxHashLog creates (or truncates) the hash log file
+func NewTxHashLog(filename string) (*TxHashLog, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &TxHashLog{
+		file:   file,
+		writer: bufio.NewWriter(file),
+	}, nil
+}
+
+// Record appends a hash to the log (thread-safe)
+func (l *TxHashLog) Record(hash common.Hash) {
+	l.mu.Lock()
+	l.writer.WriteString(hash.Hex())
+	l.writer.WriteByte('\n')
+	l.mu.Unlock()
+}
+
+// Close flushes buffered hashes and closes the file
+func (l *TxHashLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.writer.Flush(); err != nil {
+		l.file.Close()
+		return err
+	}
+	return l.file.Close()
+}
+
+// LoadTxHashes reads a hash log written by TxHashLog (blank lines are ignored)
+func LoadTxHashes(filename string) ([]common.Hash, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var hashes []common.Hash
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if len(strings.TrimPrefix(line, "0x")) != 64 {
+			return nil, fmt.Errorf("invalid transaction hash on line %d: %s", lineNum, line)
+		}
+		hashes = append(hashes, common.HexToHash(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return hashes, nil
+}
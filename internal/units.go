@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// Plain decimal numbers with an optional exponent ("1000", "0.5", "1e18"). big.Rat alone would
+// also take fractions ("1/3") and hex floats ("0x1p4"), which are never what an amount means.
+var decimalNumber = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// Decimal places for each supported unit suffix (case-insensitive)
+var unitDecimals = map[string]int64{
+	"wei":   0,
+	"gwei":  9,
+	"u2u":   18,
+	"ether": 18,
+}
+
+// ParseAmount converts an amount such as "1000", "0.001 U2U" or "5 gwei" to wei.
+// Numbers without a unit suffix are interpreted in defaultUnit.
+func ParseAmount(s string, defaultUnit string) (*big.Int, error) {
+	fields := strings.Fields(s)
+	var number, unit string
+	switch len(fields) {
+	case 1:
+		number, unit = fields[0], defaultUnit
+	case 2:
+		number, unit = fields[0], fields[1]
+	default:
+		return nil, fmt.Errorf("invalid amount %q: expected \"<number> [unit]\"", s)
+	}
+
+	decimals, ok := unitDecimals[strings.ToLower(unit)]
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q: unknown unit %q (use wei, gwei or U2U)", s, unit)
+	}
+
+	if !decimalNumber.MatchString(number) {
+		return nil, fmt.Errorf("invalid amount %q: %q is not a decimal number", s, number)
+	}
+	value, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q: %q is not a number", s, number)
+	}
+	if value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount %q: must not be negative", s)
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(decimals), nil)
+	value.Mul(value, new(big.Rat).SetInt(scale))
+	if !value.IsInt() {
+		return nil, fmt.Errorf("invalid amount %q: more decimal places than %s allows", s, unit)
+	}
+
+	return new(big.Int).Set(value.Num()), nil
+}
+
+// FormatU2U renders a wei amount in U2U with 6 decimal places
+func FormatU2U(wei *big.Int) string {
+	u2u := new(big.Float).Quo(
+		new(big.Float).SetInt(wei),
+		new(big.Float).SetInt(big.NewInt(1e18)),
+	)
+	return fmt.Sprintf("%.6f", u2u)
+}
@@ -0,0 +1,262 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// With target_block_utilization, the pacer follows a rate set by a controller instead of
+// tps_schedule. Every utilization_adjust_seconds the controller compares the gas used by
+// the blocks the receipt watcher scanned since its last step with the target and scales
+// the rate by up to ±50%. Raises are held while the senders don't keep up with the current
+// rate, so the rate can't run away from what the load generator can actually send.
+
+// Share of the rate error applied per adjustment, and the bounds of one adjustment
+const (
+	utilizationGain      = 0.5
+	utilizationMinFactor = 0.5
+	utilizationMaxFactor = 1.5
+)
+
+// Blocks within this many percentage points of the target count as on target in the report
+const utilizationBand = 10.0
+
+// utilizationController sets the send rate from the block gas utilization (a rate source for ratePacer)
+type utilizationController struct {
+	b        *Benchmark
+	target   float64 // Fraction of block gas
+	gasLimit uint64  // block_gas_limit (0 = each block's own gas limit)
+	maxTPS   float64 // 0 = no cap
+	interval time.Duration
+
+	mu        sync.Mutex
+	start     time.Time
+	segments  []TPSPoint // Rate changes since start; the rate is constant between them
+	baseTotal float64    // Sends due up to the start of the last segment
+	frozen    bool       // The send window closed; later blocks are not counted
+
+	// Since the last adjustment
+	lastAdjust   time.Time
+	sentAtAdjust uint64
+	windowUtil   float64
+	windowBlocks int
+
+	// Over the measured window
+	blocks        uint64
+	totalUtil     float64
+	onTarget      uint64
+	adjustments   uint64
+	heldIncreases uint64
+}
+
+// newUtilizationController validates the target_block_utilization settings (nil when unset)
+func newUtilizationController(config *Config) (*utilizationController, error) {
+	if config.TargetBlockUtilization == 0 {
+		return nil, nil
+	}
+	if config.TargetBlockUtilization < 0 || config.TargetBlockUtilization > 100 {
+		return nil, fmt.Errorf("target_block_utilization must be a percentage between 0 and 100 (got %g)", config.TargetBlockUtilization)
+	}
+	if len(config.TPSSchedule) > 0 {
+		return nil, fmt.Errorf("target_block_utilization and tps_schedule both set the send rate; use one of them")
+	}
+	if !config.TrackConfirmations {
+		return nil, fmt.Errorf("target_block_utilization needs track_confirmations (the block scan measures the utilization)")
+	}
+	c := &utilizationController{
+		target:   config.TargetBlockUtilization / 100,
+		gasLimit: config.BlockGasLimit,
+		maxTPS:   config.UtilizationMaxTPS,
+		interval: config.GetUtilizationAdjustInterval(),
+	}
+	c.Reset(config.GetUtilizationStartTPS())
+	return c, nil
+}
+
+// Reset restarts the rate history at rate and zeroes the statistics (start of the measured window)
+func (c *utilizationController) Reset(rate float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.start = time.Now()
+	c.segments = []TPSPoint{{At: 0, TPS: rate}}
+	c.baseTotal = 0
+	c.lastAdjust = c.start
+	c.sentAtAdjust = 0
+	c.windowUtil, c.windowBlocks = 0, 0
+	c.blocks, c.totalUtil, c.onTarget = 0, 0, 0
+	c.adjustments, c.heldIncreases = 0, 0
+}
+
+// current returns the rate in force
+func (c *utilizationController) current() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.segments[len(c.segments)-1].TPS
+}
+
+// Rate returns the target TPS t seconds into the window
+func (c *utilizationController) Rate(t float64) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := len(c.segments) - 1; i > 0; i-- {
+		if t >= c.segments[i].At {
+			return c.segments[i].TPS
+		}
+	}
+	return c.segments[0].TPS
+}
+
+// Total returns how many sends were due in the first t seconds
+func (c *utilizationController) Total(t float64) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	last := c.segments[len(c.segments)-1]
+	if t >= last.At {
+		return c.baseTotal + last.TPS*(t-last.At) // The pacer's case, kept cheap
+	}
+	total := 0.0
+	for i, s := range c.segments {
+		end := t
+		if i+1 < len(c.segments) {
+			end = min(t, c.segments[i+1].At)
+		}
+		if end <= s.At {
+			break
+		}
+		total += s.TPS * (end - s.At)
+	}
+	return total
+}
+
+// Freeze stops counting blocks when the send window closes
+func (c *utilizationController) Freeze() {
+	c.mu.Lock()
+	c.frozen = true
+	c.mu.Unlock()
+}
+
+// observe records the gas use of one scanned block and adjusts the rate once per interval
+func (c *utilizationController) observe(gasUsed, blockGasLimit uint64) {
+	limit := c.gasLimit
+	if limit == 0 {
+		limit = blockGasLimit
+	}
+	if limit == 0 {
+		return
+	}
+	util := float64(gasUsed) / float64(limit)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.frozen {
+		return
+	}
+	c.blocks++
+	c.totalUtil += util
+	if math.Abs(util-c.target)*100 <= utilizationBand {
+		c.onTarget++
+	}
+	c.windowUtil += util
+	c.windowBlocks++
+	if time.Since(c.lastAdjust) >= c.interval {
+		c.adjust()
+	}
+}
+
+// adjust scales the rate towards the target from the blocks seen since the last step (caller holds c.mu)
+func (c *utilizationController) adjust() {
+	now := time.Now()
+	util := c.windowUtil / float64(c.windowBlocks)
+	rate := c.segments[len(c.segments)-1].TPS
+	factor := 1 + utilizationGain*(c.target-util)/c.target
+	next := rate * min(max(factor, utilizationMinFactor), utilizationMaxFactor)
+
+	// Hold raises while the senders fall more than 20% short of the current rate
+	sent := atomic.LoadUint64(&c.b.sentCount)
+	if sent >= c.sentAtAdjust && next > rate {
+		achieved := float64(sent-c.sentAtAdjust) / now.Sub(c.lastAdjust).Seconds()
+		if achieved < rate*0.8 {
+			next = rate
+			c.heldIncreases++
+		}
+	}
+	next = max(next, 1)
+	if c.maxTPS > 0 {
+		next = min(next, c.maxTPS)
+	}
+
+	t := now.Sub(c.start).Seconds()
+	last := c.segments[len(c.segments)-1]
+	c.baseTotal += last.TPS * (t - last.At)
+	c.segments = append(c.segments, TPSPoint{At: t, TPS: next})
+	c.adjustments++
+	c.lastAdjust = now
+	c.sentAtAdjust = sent
+	c.windowUtil, c.windowBlocks = 0, 0
+}
+
+// UtilizationStats compares the block gas utilization held by the controller with its target
+type UtilizationStats struct {
+	TargetPercent   float64 `json:"target_percent"`
+	AvgPercent      float64 `json:"avg_percent"` // Mean over the blocks scanned in the window
+	Blocks          uint64  `json:"blocks"`
+	OnTargetPercent float64 `json:"on_target_percent"` // Blocks within 10 percentage points of the target
+	SettledTPS      float64 `json:"settled_tps"`       // Mean rate over the second half of the window
+	FinalTPS        float64 `json:"final_tps"`
+	Adjustments     uint64  `json:"adjustments"`
+	HeldIncreases   uint64  `json:"held_increases,omitempty"` // Raises skipped because the senders fell behind
+}
+
+// utilizationStats summarises the controller (nil without target_block_utilization)
+func (b *Benchmark) utilizationStats() *UtilizationStats {
+	c := b.utilization
+	if c == nil {
+		return nil
+	}
+	window := b.endTime.Sub(b.startTime()).Seconds()
+	if b.endTime.IsZero() {
+		window = time.Since(b.startTime()).Seconds()
+	}
+	stats := &UtilizationStats{TargetPercent: c.target * 100, FinalTPS: c.current()}
+	if window > 0 {
+		stats.SettledTPS = (c.Total(window) - c.Total(window/2)) / (window / 2)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats.Blocks = c.blocks
+	stats.Adjustments = c.adjustments
+	stats.HeldIncreases = c.heldIncreases
+	if c.blocks > 0 {
+		stats.AvgPercent = c.totalUtil / float64(c.blocks) * 100
+		stats.OnTargetPercent = float64(c.onTarget) / float64(c.blocks) * 100
+	}
+	return stats
+}
+
+// printUtilizationReport shows the utilization achieved against the target and the rate that held it
+func (b *Benchmark) printUtilizationReport() {
+	stats := b.utilizationStats()
+	if stats == nil {
+		return
+	}
+	fmt.Printf("\n🧱 Block Utilization (target %.0f%%):\n", stats.TargetPercent)
+	if stats.Blocks == 0 {
+		fmt.Printf("  ⚠️  No blocks were scanned in the send window\n")
+		return
+	}
+	fmt.Printf("  %-20s%.1f%% over %d blocks (%.0f%% within ±%.0f points of the target)\n",
+		"Average:", stats.AvgPercent, stats.Blocks, stats.OnTargetPercent, utilizationBand)
+	fmt.Printf("  %-20s%.1f TPS (second half of the window; final %.1f TPS)\n", "Settled Rate:", stats.SettledTPS, stats.FinalTPS)
+	fmt.Printf("  %-20s%d", "Adjustments:", stats.Adjustments)
+	if stats.HeldIncreases > 0 {
+		fmt.Printf(" (%d raises held while the senders fell behind)", stats.HeldIncreases)
+	}
+	fmt.Println()
+	if b.utilization.maxTPS > 0 && stats.FinalTPS >= b.utilization.maxTPS && stats.AvgPercent < stats.TargetPercent-utilizationBand {
+		fmt.Printf("  ⚠️  Held at utilization_max_tps (%.0f) below the target utilization\n", b.utilization.maxTPS)
+	}
+}
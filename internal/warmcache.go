@@ -0,0 +1,43 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/unicornultrafoundation/go-u2u/core/types"
+	"github.com/unicornultrafoundation/go-u2u/crypto"
+)
+
+// txTemplate holds a worker's pre-computed signature for warm-cache mode.
+//
+// EXPERIMENTAL: warm-cache mode is an upper-bound microbenchmark of the RPC
+// submission path. Each worker signs one template transaction up front and
+// then reuses that signature with only the nonce changed, so the ECDSA cost
+// disappears from the send loop. Every transaction after the first therefore
+// carries a signature that does not match its contents: the node recovers a
+// different sender and will usually reject it. Never use this mode to judge
+// inclusion or realistic throughput.
+type txTemplate struct {
+	signer types.Signer
+	sig    []byte
+}
+
+// newTxTemplate signs a template transaction from the worker's builder at the account's current nonce
+func (b *Benchmark) newTxTemplate(builder TxBuilder, account *AccountSender) (*txTemplate, error) {
+	tx, err := builder.Build(account, account.CurrentNonce())
+	if err != nil {
+		return nil, fmt.Errorf("failed to build template transaction: %v", err)
+	}
+
+	signer := types.LatestSignerForChainID(account.chainID)
+	sig, err := crypto.Sign(signer.Hash(tx).Bytes(), account.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign template transaction: %v", err)
+	}
+
+	return &txTemplate{signer: signer, sig: sig}, nil
+}
+
+// apply attaches the cached signature to a transaction without re-signing it
+func (t *txTemplate) apply(tx *types.Transaction) (*types.Transaction, error) {
+	return tx.WithSignature(t.signer, t.sig)
+}
@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// runWarmup lets the workers send for WarmupDuration seconds, then discards
+// everything measured so far so cold connections don't skew the results.
+// Returns false if a stop was requested during warmup.
+func (b *Benchmark) runWarmup() bool {
+	warmup := time.Duration(b.config.WarmupDuration) * time.Second
+	if warmup <= 0 {
+		return true
+	}
+
+	b.phases.Begin("warmup")
+	fmt.Printf("🔥 Warming up for %v (excluded from metrics)...\n", warmup)
+	select {
+	case <-time.After(warmup):
+	case <-b.stopRequested:
+		return false
+	}
+
+	b.resetMetrics()
+	fmt.Printf("✅ Warmup complete, measuring\n")
+	return true
+}
+
+// resetMetrics zeroes all counters while workers keep running
+func (b *Benchmark) resetMetrics() {
+	atomic.StoreUint64(&b.sentCount, 0)
+	atomic.StoreUint64(&b.errorCount, 0)
+	atomic.StoreUint64(&b.retryCount, 0)
+	atomic.StoreUint64(&b.rateLimited, 0)
+	atomic.StoreUint64(&b.alreadyKnown, 0)
+	atomic.StoreUint64(&b.nonceErrors, 0)
+	atomic.StoreUint64(&b.nonceResyncs, 0)
+	atomic.StoreInt64(&b.totalLatency, 0)
+	b.latencies.Reset()
+	b.extremes.Reset()
+	b.errorSamples.Reset()
+	b.errorKinds.Reset()
+	b.signing.Reset()
+	b.resetTypeStats()
+
+	for _, account := range b.accounts {
+		atomic.StoreUint64(&account.sent, 0)
+		atomic.StoreUint64(&account.errors, 0)
+		atomic.StoreUint64(&account.received, 0)
+		atomic.StoreInt64(&account.latency, 0)
+	}
+
+	if b.watcher != nil {
+		b.watcher.Reset()
+	}
+	if b.pool != nil {
+		b.pool.Reset()
+	}
+	if b.transport != nil {
+		b.transport.Reset()
+	}
+	if b.propagation != nil {
+		b.propagation.Reset()
+	}
+
+	b.markStartBlock()
+	b.markStart()
+	if b.utilization != nil {
+		b.utilization.Reset(b.utilization.current()) // Keep the rate found during warmup
+	}
+	if b.pacer != nil {
+		b.pacer.Reset()
+	}
+}
+
+// coldStartCost compares the first measured interval with the steady state
+// (the median of the intervals in between the first and the last one).
+// Returns ok=false when there are too few intervals to compare.
+func coldStartCost(tpsHistory []uint64, latencyHistory []time.Duration) (latencyCost time.Duration, tpsPercent float64, ok bool) {
+	if len(tpsHistory) < 3 || len(latencyHistory) < 3 {
+		return 0, 0, false
+	}
+
+	_, _, steadyTPS := calculateTPSStats(tpsHistory[1 : len(tpsHistory)-1])
+	steadyLatency := medianDuration(latencyHistory[1 : len(latencyHistory)-1])
+	if steadyTPS == 0 {
+		return 0, 0, false
+	}
+
+	latencyCost = latencyHistory[0] - steadyLatency
+	tpsPercent = float64(tpsHistory[0]) / float64(steadyTPS) * 100
+	return latencyCost, tpsPercent, true
+}
+
+func medianDuration(values []time.Duration) time.Duration {
+	asUint := make([]uint64, len(values))
+	for i, v := range values {
+		if v > 0 {
+			asUint[i] = uint64(v)
+		}
+	}
+	_, _, median := calculateTPSStats(asUint)
+	return time.Duration(median)
+}
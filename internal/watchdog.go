@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// startupWatchdog stops the run if nothing has succeeded within StartupGracePeriod,
+// so a misconfigured run fails fast instead of erroring for its full duration.
+func (b *Benchmark) startupWatchdog() {
+	grace := time.Duration(b.config.StartupGracePeriod) * time.Second
+	if grace <= 0 {
+		return
+	}
+
+	select {
+	case <-time.After(grace):
+	case <-b.stopRequested:
+		return
+	}
+
+	if atomic.LoadUint32(&b.anySucceeded) != 0 {
+		return
+	}
+
+	fmt.Printf("\n🛑 No transaction succeeded in the first %v, aborting.\n", grace)
+	if err := b.firstError.Load(); err != nil {
+		fmt.Printf("   First error: %v\n", *err)
+	}
+	fmt.Printf("   Likely causes:\n")
+	fmt.Printf("   - Wrong or unreachable RPC endpoint (check rpc_url)\n")
+	fmt.Printf("   - Underfunded accounts (run cmd/fund, or cmd/check to inspect balances)\n")
+	fmt.Printf("   - Wrong chain ID (check chain_id / the node's eth_chainId)\n")
+	fmt.Printf("   - Gas price below the node's minimum (check fixed_gas_price_wei)\n")
+
+	b.requestStop(fmt.Sprintf("no successful transaction within the %v startup grace period", grace))
+}
+
+// recordError remembers the first send error for the startup watchdog
+// and adds it to the error samples
+func (b *Benchmark) recordError(err error) {
+	if b.firstError.Load() == nil {
+		b.firstError.CompareAndSwap(nil, &err)
+	}
+	b.errorSamples.Record(err)
+}
@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/unicornultrafoundation/go-u2u/common"
+)
+
+// Workloads
+const (
+	WorkloadTransfer = "transfer" // Signed value transfers (default)
+	WorkloadERC20    = "erc20"    // ERC-20 transfer() calls on erc20_token_address
+	WorkloadDeploy   = "deploy"   // Contract deployments of deploy_bytecode
+	WorkloadCall     = "call"     // Calls to call_function on call_contract_address with templated arguments
+	WorkloadRead     = "read"     // eth_getBalance queries; no signing or nonces
+	WorkloadReplay   = "replay"   // Recorded transfers from workload_file, in file order per sender
+)
+
+// validateWorkload checks the configured workload name and its parameters
+func validateWorkload(config *Config) error {
+	if len(config.WorkloadMix) > 0 {
+		return validateWorkloadMix(config)
+	}
+	switch config.Workload {
+	case "", WorkloadTransfer, WorkloadRead:
+		return nil
+	case WorkloadERC20:
+		_, _, err := config.erc20Params()
+		return err
+	case WorkloadDeploy:
+		_, err := config.deployCode()
+		return err
+	case WorkloadCall:
+		_, err := config.callSpec()
+		return err
+	case WorkloadReplay:
+		_, err := config.replayWorkload()
+		return err
+	default:
+		return fmt.Errorf("unknown workload %q (use %q, %q, %q, %q, %q or %q)",
+			config.Workload, WorkloadTransfer, WorkloadERC20, WorkloadDeploy, WorkloadCall, WorkloadRead, WorkloadReplay)
+	}
+}
+
+// TxValue returns the native value attached to each transaction: transfer_amount_wei
+// for transfers, call_value_wei for contract calls, zero for token transfers and deployments,
+// and the largest recorded value for replays
+func (c *Config) TxValue() (*big.Int, error) {
+	if len(c.WorkloadMix) > 0 {
+		return c.mixTxValue()
+	}
+	value, err := c.TransferValue()
+	if err != nil {
+		return nil, err
+	}
+	switch c.Workload {
+	case WorkloadERC20, WorkloadDeploy:
+		return new(big.Int), nil
+	case WorkloadCall:
+		if c.CallValueWei == "" {
+			return new(big.Int), nil
+		}
+		return ParseAmount(c.CallValueWei, "wei")
+	case WorkloadReplay:
+		w, err := c.replayWorkload()
+		if err != nil {
+			return nil, err
+		}
+		return w.maxValue, nil
+	}
+	return value, nil
+}
+
+// describeWorkload returns a one-line description for the configuration banner
+func describeWorkload(config *Config, transferValue *big.Int) string {
+	if len(config.WorkloadMix) > 0 {
+		return describeMix(config)
+	}
+	switch config.Workload {
+	case WorkloadRead:
+		return "read (eth_getBalance queries, no transactions)"
+	case WorkloadERC20:
+		token, amount, _ := config.erc20Params()
+		return fmt.Sprintf("erc20 (transfer %s units of %s per tx)", amount.String(), token.Hex())
+	case WorkloadDeploy:
+		code, _ := config.deployCode()
+		return fmt.Sprintf("deploy (%d bytes of init code per tx)", len(code))
+	case WorkloadCall:
+		spec, _ := config.callSpec()
+		return fmt.Sprintf("call (%s on %s, %s wei per call)", spec.describe(), spec.to.Hex(), spec.value.String())
+	case WorkloadReplay:
+		w, _ := config.replayWorkload()
+		return fmt.Sprintf("replay (%d recorded txs for %d senders from %s, looped)", w.total, len(w.rows), config.WorkloadFile)
+	default:
+		return fmt.Sprintf("transfer (%s wei per tx)", transferValue.String())
+	}
+}
+
+// IsReadWorkload reports whether the run issues queries instead of transactions
+func (c *Config) IsReadWorkload() bool {
+	return c.Workload == WorkloadRead
+}
+
+// rateLabel names the per-interval rate in reports
+func (b *Benchmark) rateLabel() string {
+	if b.config.IsReadWorkload() {
+		return "Queries/s"
+	}
+	return "Submitted TPS"
+}
+
+// send performs one unit of work for the configured workload
+// and returns the transaction hash (zero for reads, which have no builder)
+func (b *Benchmark) send(ctx context.Context, accountID int, account *AccountSender, builder TxBuilder, template *txTemplate) (common.Hash, error) {
+	if b.config.IsReadWorkload() {
+		return common.Hash{}, b.readBalance(ctx, accountID, account)
+	}
+	return b.sendTransaction(ctx, accountID, account, builder, template)
+}
+
+// readBalance queries the balance of the account's pattern recipient
+func (b *Benchmark) readBalance(ctx context.Context, accountID int, account *AccountSender) error {
+	target := b.accounts[b.recipientFor(accountID)]
+	_, err := account.client.BalanceAt(ctx, target.from, nil)
+	return err
+}
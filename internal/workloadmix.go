@@ -0,0 +1,275 @@
+package internal
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/unicornultrafoundation/go-u2u/core/types"
+)
+
+// A workload mix sends several transaction types in one run: every transaction picks one
+// of the workload_mix types at random, by weight, and each type gets its own counters so
+// the report can show, e.g., transfers at 800 TPS next to contract calls at 120 TPS.
+
+// mixTypes returns the workload_mix types in a stable order (nil without a mix)
+func (c *Config) mixTypes() []string {
+	if len(c.WorkloadMix) == 0 {
+		return nil
+	}
+	types := make([]string, 0, len(c.WorkloadMix))
+	for name := range c.WorkloadMix {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// forWorkload returns a copy of the config running only the given workload
+func (c *Config) forWorkload(name string) *Config {
+	single := *c
+	single.Workload = name
+	single.WorkloadMix = nil
+	return &single
+}
+
+// validateWorkloadMix checks the mix weights and every mixed workload's parameters
+func validateWorkloadMix(config *Config) error {
+	if config.Workload == WorkloadRead {
+		return fmt.Errorf("workload_mix cannot be combined with the %q workload", WorkloadRead)
+	}
+	if config.WarmCacheMode {
+		return fmt.Errorf("workload_mix cannot be used with warm_cache_mode")
+	}
+	for _, name := range config.mixTypes() {
+		switch name {
+		case WorkloadTransfer, WorkloadERC20, WorkloadDeploy, WorkloadCall:
+		default:
+			return fmt.Errorf("workload_mix: %q cannot be mixed (use %q, %q, %q or %q)",
+				name, WorkloadTransfer, WorkloadERC20, WorkloadDeploy, WorkloadCall)
+		}
+		if weight := config.WorkloadMix[name]; weight <= 0 {
+			return fmt.Errorf("workload_mix: weight of %q must be greater than zero, got %g", name, weight)
+		}
+		if err := validateWorkload(config.forWorkload(name)); err != nil {
+			return fmt.Errorf("workload_mix: %v", err)
+		}
+	}
+	return nil
+}
+
+// mixTxValue is the largest native value any mixed type attaches (for balance and spend estimates)
+func (c *Config) mixTxValue() (*big.Int, error) {
+	largest := new(big.Int)
+	for _, name := range c.mixTypes() {
+		value, err := c.forWorkload(name).TxValue()
+		if err != nil {
+			return nil, err
+		}
+		if value.Cmp(largest) > 0 {
+			largest = value
+		}
+	}
+	return largest, nil
+}
+
+// checkMixGasLimit runs the gas limit check for every mixed type
+func checkMixGasLimit(config *Config) (string, error) {
+	var warnings []string
+	for _, name := range config.mixTypes() {
+		warning, err := checkGasLimit(config.forWorkload(name))
+		if err != nil {
+			return "", fmt.Errorf("workload_mix: %v", err)
+		}
+		if warning != "" {
+			warnings = append(warnings, name+": "+warning)
+		}
+	}
+	return strings.Join(warnings, "; "), nil
+}
+
+// describeMix returns the banner description of a workload mix
+func describeMix(config *Config) string {
+	total := 0.0
+	for _, weight := range config.WorkloadMix {
+		total += weight
+	}
+	parts := make([]string, 0, len(config.WorkloadMix))
+	for _, name := range config.mixTypes() {
+		parts = append(parts, fmt.Sprintf("%s %.0f%%", name, config.WorkloadMix[name]/total*100))
+	}
+	return "mix (" + strings.Join(parts, ", ") + ")"
+}
+
+// selectMixedBuilder sets up one builder per mixed type and a per-type counter each
+func (b *Benchmark) selectMixedBuilder() error {
+	types := b.config.mixTypes()
+	factories := make([]func(int, *rand.Rand) TxBuilder, len(types))
+	cumulative := make([]float64, len(types))
+	total := 0.0
+	for i, name := range types {
+		factory, err := b.builderFactory(name)
+		if err != nil {
+			return err
+		}
+		factories[i] = factory
+		total += b.config.WorkloadMix[name]
+		cumulative[i] = total
+	}
+
+	b.txTypes = types
+	b.typeStats = make([]txTypeStats, len(types))
+	b.newBuilder = func(accountID int, rng *rand.Rand) TxBuilder {
+		m := &mixedBuilder{rng: rng, cumulative: cumulative, builders: make([]TxBuilder, len(factories))}
+		for i, factory := range factories {
+			m.builders[i] = factory(accountID, rng)
+		}
+		return m
+	}
+	return nil
+}
+
+// mixedBuilder builds each transaction with one of its builders, picked by weight
+type mixedBuilder struct {
+	rng        *rand.Rand
+	builders   []TxBuilder
+	cumulative []float64 // Running total of the weights, in mixTypes order
+	last       int       // Type of the last built transaction
+}
+
+func (m *mixedBuilder) Build(account *AccountSender, nonce uint64) (*types.Transaction, error) {
+	pick := m.rng.Float64() * m.cumulative[len(m.cumulative)-1]
+	m.last = sort.SearchFloat64s(m.cumulative, pick)
+	if m.last >= len(m.builders) {
+		m.last = len(m.builders) - 1
+	}
+	return m.builders[m.last].Build(account, nonce)
+}
+
+// LastRecipient forwards to the last used builder (nil for types without a benchmark recipient)
+func (m *mixedBuilder) LastRecipient() *AccountSender {
+	if r, ok := m.builders[m.last].(recipientReporter); ok {
+		return r.LastRecipient()
+	}
+	return nil
+}
+
+// txTypeOf returns the type index of the builder's last transaction (0 outside a mix)
+func txTypeOf(builder TxBuilder) int {
+	if m, ok := builder.(*mixedBuilder); ok {
+		return m.last
+	}
+	return 0
+}
+
+// txTypeStats counts one transaction type of a workload mix (atomic)
+type txTypeStats struct {
+	sent    uint64
+	errors  uint64
+	latency int64 // Cumulative latency of successful sends (nanoseconds)
+}
+
+// recordTypeSent counts a successful send of the builder's last transaction type
+func (b *Benchmark) recordTypeSent(builder TxBuilder, latency time.Duration) {
+	if b.typeStats == nil {
+		return
+	}
+	s := &b.typeStats[txTypeOf(builder)]
+	atomic.AddUint64(&s.sent, 1)
+	atomic.AddInt64(&s.latency, latency.Nanoseconds())
+}
+
+// recordTypeError counts a failed send of the builder's last transaction type
+func (b *Benchmark) recordTypeError(builder TxBuilder) {
+	if b.typeStats == nil {
+		return
+	}
+	atomic.AddUint64(&b.typeStats[txTypeOf(builder)].errors, 1)
+}
+
+// resetTypeStats zeroes the per-type counters (end of warmup)
+func (b *Benchmark) resetTypeStats() {
+	for i := range b.typeStats {
+		s := &b.typeStats[i]
+		atomic.StoreUint64(&s.sent, 0)
+		atomic.StoreUint64(&s.errors, 0)
+		atomic.StoreInt64(&s.latency, 0)
+	}
+}
+
+// TxTypeResult is the breakdown of one transaction type of a workload mix
+type TxTypeResult struct {
+	Type         string  `json:"type"`
+	Weight       float64 `json:"weight"`
+	Sent         uint64  `json:"sent"`
+	Errors       uint64  `json:"errors"`
+	ErrorRate    float64 `json:"error_rate"` // Percent of attempts that failed
+	SubmittedTPS float64 `json:"submitted_tps"`
+	AvgLatencyMs int64   `json:"avg_latency_ms"`
+	Confirmed    uint64  `json:"confirmed,omitempty"`
+	ConfirmedTPS float64 `json:"confirmed_tps,omitempty"`
+
+	avgLatency time.Duration
+}
+
+// txTypeResults collects the per-type breakdown (nil without a workload mix)
+func (b *Benchmark) txTypeResults(elapsed time.Duration) []TxTypeResult {
+	if b.typeStats == nil {
+		return nil
+	}
+	results := make([]TxTypeResult, len(b.txTypes))
+	for i, name := range b.txTypes {
+		s := &b.typeStats[i]
+		r := TxTypeResult{
+			Type:   name,
+			Weight: b.config.WorkloadMix[name],
+			Sent:   atomic.LoadUint64(&s.sent),
+			Errors: atomic.LoadUint64(&s.errors),
+		}
+		if attempts := r.Sent + r.Errors; attempts > 0 {
+			r.ErrorRate = float64(r.Errors) / float64(attempts) * 100
+		}
+		if r.Sent > 0 {
+			r.avgLatency = time.Duration(atomic.LoadInt64(&s.latency) / int64(r.Sent))
+			r.AvgLatencyMs = r.avgLatency.Milliseconds()
+		}
+		if elapsed > 0 {
+			r.SubmittedTPS = float64(r.Sent) / elapsed.Seconds()
+		}
+		if b.watcher != nil {
+			r.Confirmed = b.watcher.TypeConfirmed(i)
+			if elapsed > 0 {
+				r.ConfirmedTPS = float64(r.Confirmed) / elapsed.Seconds()
+			}
+		}
+		results[i] = r
+	}
+	return results
+}
+
+// printTxTypeReport shows the per-type breakdown of a workload mix
+func (b *Benchmark) printTxTypeReport(elapsed time.Duration) {
+	results := b.txTypeResults(elapsed)
+	if results == nil {
+		return
+	}
+
+	fmt.Printf("\n🧩 Transaction Types:\n")
+	header := fmt.Sprintf("  %-10s | %-8s | %-9s | %-10s | %-11s", "Type", "Sent", "TPS", "Error Rate", "Avg Latency")
+	if b.watcher != nil {
+		header += fmt.Sprintf(" | %-9s | %-9s", "Confirmed", "Conf. TPS")
+	}
+	fmt.Println(header)
+	for _, r := range results {
+		line := fmt.Sprintf("  %-10s | %-8d | %-9.2f | %-10s | %-11s",
+			r.Type, r.Sent, r.SubmittedTPS, fmt.Sprintf("%.1f%%", r.ErrorRate), b.roundLatency(r.avgLatency))
+		if b.watcher != nil {
+			line += fmt.Sprintf(" | %-9d | %-9.2f", r.Confirmed, r.ConfirmedTPS)
+		}
+		fmt.Println(line)
+	}
+}